@@ -3,11 +3,14 @@ module github.com/vsemashko/go-pii-sanitizer
 go 1.21
 
 require (
+	github.com/go-kit/log v0.2.1
+	github.com/go-logr/logr v1.4.2
 	github.com/rs/zerolog v1.33.0
 	go.uber.org/zap v1.27.0
 )
 
 require (
+	github.com/go-logfmt/logfmt v0.5.1 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.19 // indirect
 	go.uber.org/multierr v1.10.0 // indirect
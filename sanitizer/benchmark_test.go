@@ -1,7 +1,13 @@
 package sanitizer
 
 import (
+	"io"
+	"log/slog"
 	"testing"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // Benchmark tests for performance measurement
@@ -167,3 +173,72 @@ func BenchmarkUAEIBAN(b *testing.B) {
 		s.SanitizeField("text", text)
 	}
 }
+
+// Message sanitization benchmarks compare the default logger integrations with
+// message sanitization switched on, for a message with and without PII
+
+var benchmarkMessages = []struct{ name, msg string }{
+	{"NoPII", "order ORD-12345 shipped to warehouse 7"},
+	{"PII", "sent OTP to +6591234567 for user@example.com"},
+}
+
+func BenchmarkSlogHandler_Message(b *testing.B) {
+	s := NewDefault()
+	handler := NewSlogHandler(slog.NewJSONHandler(io.Discard, nil), s)
+
+	for _, enabled := range []bool{false, true} {
+		logger := slog.New(handler.WithMessageSanitization(enabled))
+		for _, bm := range benchmarkMessages {
+			msg := bm.msg
+			b.Run(benchmarkMessageName(enabled, bm.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					logger.Info(msg, "orderId", "ORD-123")
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkZapCore_Message(b *testing.B) {
+	s := NewDefault()
+	inner := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(io.Discard), zapcore.InfoLevel)
+
+	for _, enabled := range []bool{false, true} {
+		logger := zap.New(WrapZapCore(inner, s).WithMessageSanitization(enabled))
+		for _, bm := range benchmarkMessages {
+			msg := bm.msg
+			b.Run(benchmarkMessageName(enabled, bm.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					logger.Info(msg, zap.String("orderId", "ORD-123"))
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkZerolog_Message(b *testing.B) {
+	s := NewDefault()
+
+	for _, enabled := range []bool{false, true} {
+		logger := zerolog.New(ZerologWriter(io.Discard, s))
+		if enabled {
+			logger = logger.Hook(ZerologHook(s))
+		}
+		for _, bm := range benchmarkMessages {
+			msg := bm.msg
+			b.Run(benchmarkMessageName(enabled, bm.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					logger.Info().Str("orderId", "ORD-123").Msg(msg)
+				}
+			})
+		}
+	}
+}
+
+// benchmarkMessageName names a message sanitization sub-benchmark
+func benchmarkMessageName(enabled bool, message string) string {
+	if enabled {
+		return "Sanitized/" + message
+	}
+	return "Default/" + message
+}
@@ -27,6 +27,66 @@ const (
 
 	// HongKong enables Hong Kong-specific patterns (HKID, phone)
 	HongKong Region = "HK"
+
+	// India enables India-specific patterns (Aadhaar, PAN, UPI ID, phone).
+	// Not enabled by default; add it explicitly with WithRegions.
+	India Region = "IN"
+
+	// SaudiArabia enables Saudi-specific patterns (National ID/Iqama, IBAN, phone).
+	// Not enabled by default.
+	SaudiArabia Region = "SA"
+
+	// Qatar enables Qatar-specific patterns (QID, IBAN, phone).
+	// Not enabled by default.
+	Qatar Region = "QA"
+
+	// Kuwait enables Kuwait-specific patterns (Civil ID, IBAN, phone).
+	// Not enabled by default.
+	Kuwait Region = "KW"
+
+	// Bahrain enables Bahrain-specific patterns (CPR, IBAN, phone).
+	// Not enabled by default.
+	Bahrain Region = "BH"
+
+	// Oman enables Oman-specific patterns (Civil Number, IBAN, phone).
+	// Not enabled by default.
+	Oman Region = "OM"
+
+	// China enables mainland China patterns (Resident ID, Home Return Permit, Macau BIR, phone).
+	// Not enabled by default.
+	China Region = "CN"
+
+	// Japan enables Japan-specific patterns (My Number, phone, romanized and kana name fields).
+	// Not enabled by default.
+	Japan Region = "JP"
+
+	// Australia enables Australia-specific patterns (TFN, Medicare, ABN, phone).
+	// Not enabled by default.
+	Australia Region = "AU"
+
+	// UnitedKingdom enables UK-specific patterns (NINO, NHS number, IBAN).
+	// Not enabled by default.
+	UnitedKingdom Region = "GB"
+
+	// Germany enables Germany-specific patterns (Steuer-ID, IBAN).
+	// Not enabled by default.
+	Germany Region = "DE"
+
+	// France enables France-specific patterns (NIR, IBAN).
+	// Not enabled by default.
+	France Region = "FR"
+
+	// Spain enables Spain-specific patterns (DNI, NIE, IBAN).
+	// Not enabled by default.
+	Spain Region = "ES"
+
+	// Italy enables Italy-specific patterns (Codice Fiscale, IBAN).
+	// Not enabled by default.
+	Italy Region = "IT"
+
+	// Netherlands enables Netherlands-specific patterns (BSN, IBAN).
+	// Not enabled by default.
+	Netherlands Region = "NL"
 )
 
 // RedactionStrategy defines how PII should be redacted when detected.
@@ -76,6 +136,12 @@ type Config struct {
 	// Custom patterns (advanced)
 	CustomFieldPatterns   map[string][]string
 	CustomContentPatterns []ContentPattern
+
+	// Path-based rules from a JSON Schema (see LoadJSONSchema)
+	Schema *Schema
+
+	// PII types from Schema annotations to never redact (e.g. "email")
+	NeverRedactTypes []string
 }
 
 // NewDefaultConfig creates a Config with sensible defaults
@@ -106,6 +172,14 @@ func (c *Config) WithPreserve(fields ...string) *Config {
 	return c
 }
 
+// WithPreserveTypes adds PII types to the preserve list for Schema annotations.
+// Unlike WithPreserve, it applies to properties annotated with the type whatever
+// their name, and not to fields named like the type.
+func (c *Config) WithPreserveTypes(types ...string) *Config {
+	c.NeverRedactTypes = append(c.NeverRedactTypes, types...)
+	return c
+}
+
 // WithStrategy sets the redaction strategy
 func (c *Config) WithStrategy(strategy RedactionStrategy) *Config {
 	c.Strategy = strategy
@@ -0,0 +1,178 @@
+package sanitizer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+// CSVOptions configures SanitizeCSV. The zero value reads and writes
+// comma-separated values with standard quoting.
+type CSVOptions struct {
+	// Comma is the field delimiter (default ','); use '\t' for TSV
+	Comma rune
+
+	// Comment, if not 0, marks lines to skip in the input (e.g. '#')
+	Comment rune
+
+	// LazyQuotes accepts quotes in unquoted fields and unescaped quotes in quoted fields
+	LazyQuotes bool
+
+	// TrimLeadingSpace ignores leading white space in input fields
+	TrimLeadingSpace bool
+
+	// UseCRLF terminates output lines with \r\n instead of \n
+	UseCRLF bool
+}
+
+// CSVColumnClass describes how a CSV column is sanitized
+type CSVColumnClass string
+
+const (
+	// CSVColumnRedacted columns match a PII or redact rule by name; every non-empty cell is redacted
+	CSVColumnRedacted CSVColumnClass = "redacted"
+
+	// CSVColumnPreserved columns are explicitly preserved and copied unchanged
+	CSVColumnPreserved CSVColumnClass = "preserved"
+
+	// CSVColumnScanned columns are checked cell by cell against content patterns
+	CSVColumnScanned CSVColumnClass = "scanned"
+)
+
+// CSVColumn is the classification of a single column
+type CSVColumn struct {
+	Name  string
+	Class CSVColumnClass
+
+	// PIIType is the field name pattern that matched a redacted column
+	// (e.g. "email", "secret"), or "explicit" for AlwaysRedact fields
+	PIIType string
+
+	// Redacted counts the cells that were changed
+	Redacted int
+}
+
+// CSVSummary describes a sanitized CSV stream
+type CSVSummary struct {
+	// Columns lists the header columns in order; cells beyond the header are scanned
+	Columns []CSVColumn
+
+	// Rows counts the data rows written, excluding the header
+	Rows int
+}
+
+// SanitizeCSV streams CSV (or TSV) from r to w with PII redacted. The first row is the
+// header: each column is classified once by its name using the field name rules, then
+// every data row is sanitized by column. Cells in columns without a name match are
+// checked against content patterns. Only one row is held in memory at a time.
+//
+// Rows may have a different number of cells than the header. Under StrategyRemove
+// redacted cells are left empty so that columns stay aligned.
+//
+// Returns a summary of the column classification and redactions. On error the summary
+// covers the rows written so far.
+//
+// Example:
+//
+//	summary, err := s.SanitizeCSV(in, out, CSVOptions{Comma: '\t'})
+//	for _, col := range summary.Columns {
+//		fmt.Println(col.Name, col.Class, col.Redacted)
+//	}
+func (s *Sanitizer) SanitizeCSV(r io.Reader, w io.Writer, opts CSVOptions) (*CSVSummary, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	reader.LazyQuotes = opts.LazyQuotes
+	reader.TrimLeadingSpace = opts.TrimLeadingSpace
+	reader.Comment = opts.Comment
+
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = opts.UseCRLF
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+		writer.Comma = opts.Comma
+	}
+
+	summary := &CSVSummary{}
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return summary, nil
+		}
+		return summary, err
+	}
+	for _, name := range header {
+		summary.Columns = append(summary.Columns, s.classifyColumn(name))
+	}
+	if err := writer.Write(header); err != nil {
+		return summary, err
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			writer.Flush()
+			return summary, err
+		}
+
+		for i, cell := range record {
+			if cell == "" {
+				continue
+			}
+			class := CSVColumnScanned
+			if i < len(summary.Columns) {
+				class = summary.Columns[i].Class
+			}
+
+			var sanitized string
+			switch class {
+			case CSVColumnRedacted:
+				sanitized = s.redact(cell)
+			case CSVColumnPreserved:
+				continue
+			default:
+				sanitized = s.sanitizeContent(cell)
+			}
+			if sanitized != cell {
+				record[i] = sanitized
+				if i < len(summary.Columns) {
+					summary.Columns[i].Redacted++
+				}
+			}
+		}
+
+		if err := writer.Write(record); err != nil {
+			return summary, err
+		}
+		summary.Rows++
+	}
+
+	writer.Flush()
+	return summary, writer.Error()
+}
+
+// classifyColumn classifies a CSV column by its header name
+func (s *Sanitizer) classifyColumn(name string) CSVColumn {
+	column := CSVColumn{Name: name, Class: CSVColumnScanned}
+	// Spreadsheet exports often start with a UTF-8 byte order mark
+	fieldName := strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+	fieldNameLower := strings.ToLower(fieldName)
+
+	switch {
+	case s.explicitSafe[fieldNameLower]:
+		column.Class = CSVColumnPreserved
+	case s.explicitRedact[fieldNameLower]:
+		column.Class = CSVColumnRedacted
+		column.PIIType = "explicit"
+	default:
+		if piiType := s.fieldMatcher.matchType(fieldName); piiType != "" {
+			column.Class = CSVColumnRedacted
+			column.PIIType = piiType
+		}
+	}
+	return column
+}
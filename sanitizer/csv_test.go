@@ -0,0 +1,163 @@
+package sanitizer
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeCSV(t *testing.T) {
+	s := New(NewDefaultConfig().WithRedact("vendorRef").WithPreserve("memo"))
+
+	input := "\ufefftxnId,email,amount,memo,vendorRef,note\n" +
+		"TXN-1,john@acme.com,100.50,paid by john@acme.com,V-9,\n" +
+		"TXN-2,,20.00,refund,V-10,\"call +6591234567, then email\"\n" +
+		"TXN-3,jane@acme.com,5.00,,V-11,ok,extra@acme.com\n"
+
+	var out bytes.Buffer
+	summary, err := s.SanitizeCSV(strings.NewReader(input), &out, CSVOptions{})
+	if err != nil {
+		t.Fatalf("SanitizeCSV() error = %v", err)
+	}
+
+	expected := "\ufefftxnId,email,amount,memo,vendorRef,note\n" +
+		"TXN-1,[REDACTED],100.50,paid by john@acme.com,[REDACTED],\n" +
+		"TXN-2,,20.00,refund,[REDACTED],[REDACTED]\n" +
+		"TXN-3,[REDACTED],5.00,,[REDACTED],ok,[REDACTED]\n"
+	if out.String() != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), expected)
+	}
+
+	expectedColumns := []CSVColumn{
+		{Name: "\ufefftxnId", Class: CSVColumnScanned},
+		{Name: "email", Class: CSVColumnRedacted, PIIType: "email", Redacted: 2},
+		{Name: "amount", Class: CSVColumnScanned},
+		{Name: "memo", Class: CSVColumnPreserved},
+		{Name: "vendorRef", Class: CSVColumnRedacted, PIIType: "explicit", Redacted: 3},
+		{Name: "note", Class: CSVColumnScanned, Redacted: 1},
+	}
+	if !reflect.DeepEqual(summary.Columns, expectedColumns) {
+		t.Errorf("Columns:\ngot  %+v\nwant %+v", summary.Columns, expectedColumns)
+	}
+	if summary.Rows != 3 {
+		t.Errorf("Expected 3 rows, got %d", summary.Rows)
+	}
+}
+
+func TestSanitizeCSV_Options(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		name     string
+		opts     CSVOptions
+		input    string
+		expected string
+	}{
+		{
+			name:     "tsv",
+			opts:     CSVOptions{Comma: '\t'},
+			input:    "id\tphone\n1\t+6591234567\n",
+			expected: "id\tphone\n1\t[REDACTED]\n",
+		},
+		{
+			name:     "semicolon with crlf",
+			opts:     CSVOptions{Comma: ';', UseCRLF: true},
+			input:    "id;fullName\r\n1;John Doe\r\n",
+			expected: "id;fullName\r\n1;[REDACTED]\r\n",
+		},
+		{
+			name:     "lazy quotes",
+			opts:     CSVOptions{LazyQuotes: true},
+			input:    "id,note\n1,say \"hi\" to john@acme.com\n",
+			expected: "id,note\n1,[REDACTED]\n",
+		},
+		{
+			name:     "comments and leading space",
+			opts:     CSVOptions{Comment: '#', TrimLeadingSpace: true},
+			input:    "# exported 2024-01-15\nid, email\n1, john@acme.com\n",
+			expected: "id,email\n1,[REDACTED]\n",
+		},
+		{
+			name:     "quoting preserved where needed",
+			opts:     CSVOptions{},
+			input:    "id,address\n1,\"1 Main St, #02-01\"\n2,\"\"\n",
+			expected: "id,address\n1,[REDACTED]\n2,\n",
+		},
+		{
+			name:     "header only",
+			opts:     CSVOptions{},
+			input:    "id,email\n",
+			expected: "id,email\n",
+		},
+		{
+			name:     "empty",
+			opts:     CSVOptions{},
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if _, err := s.SanitizeCSV(strings.NewReader(tt.input), &out, tt.opts); err != nil {
+				t.Fatalf("SanitizeCSV() error = %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("got %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestSanitizeCSV_RemoveStrategy(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyRemove))
+
+	var out bytes.Buffer
+	if _, err := s.SanitizeCSV(strings.NewReader("id,email,amount\n1,john@acme.com,5\n"), &out, CSVOptions{}); err != nil {
+		t.Fatalf("SanitizeCSV() error = %v", err)
+	}
+	if out.String() != "id,email,amount\n1,,5\n" {
+		t.Errorf("Expected removed cells to be left empty, got %q", out.String())
+	}
+}
+
+func TestSanitizeCSV_ParseError(t *testing.T) {
+	s := NewDefault()
+
+	var out bytes.Buffer
+	summary, err := s.SanitizeCSV(strings.NewReader("id,email\n1,john@acme.com\n2,\"unterminated\n"), &out, CSVOptions{})
+	if err == nil {
+		t.Fatal("Expected a parse error")
+	}
+	if summary.Rows != 1 || out.String() != "id,email\n1,[REDACTED]\n" {
+		t.Errorf("Expected rows before the error to be written, got %d rows: %q", summary.Rows, out.String())
+	}
+}
+
+func TestSanitizeCSV_WriteError(t *testing.T) {
+	s := NewDefault()
+
+	_, err := s.SanitizeCSV(strings.NewReader("id\n1\n"), failingWriter{}, CSVOptions{})
+	if err == nil || err.Error() != "disk full" {
+		t.Errorf("Expected write error, got %v", err)
+	}
+}
+
+func BenchmarkSanitizeCSV(b *testing.B) {
+	s := NewDefault()
+
+	var input strings.Builder
+	input.WriteString("txnId,email,amount,note\n")
+	for i := 0; i < 1000; i++ {
+		input.WriteString("TXN-1,john@acme.com,100.50,monthly settlement\n")
+	}
+	data := input.String()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = s.SanitizeCSV(strings.NewReader(data), io.Discard, CSVOptions{})
+	}
+}
@@ -0,0 +1,52 @@
+package sanitizer
+
+// sanitizedError wraps an error, replacing its message with a sanitized copy
+type sanitizedError struct {
+	err error
+	msg string
+}
+
+// Error returns the sanitized message
+func (e *sanitizedError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the original error so errors.Is and errors.As keep working.
+// Note that the original error's own Error method still returns the unsanitized message.
+func (e *sanitizedError) Unwrap() error {
+	return e.err
+}
+
+// Error returns an error whose Error() has PII redacted in place, keeping the rest
+// of the message readable. The original error stays reachable through Unwrap, so
+// errors.Is and errors.As behave as they would on err. Returns nil if err is nil.
+//
+// Example:
+//
+//	s := NewDefault()
+//	err := s.Error(fmt.Errorf("user %s: %w", "user@example.com", ErrNotFound))
+//	err.Error()                   // "user [REDACTED]: not found"
+//	errors.Is(err, ErrNotFound)   // true
+func (s *Sanitizer) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*sanitizedError); ok {
+		return err
+	}
+	return &sanitizedError{err: err, msg: s.sanitizeText(err.Error())}
+}
+
+// sanitizeError wraps err like Error, using fieldName for field name matching.
+// Returns false if the whole message was removed (StrategyRemove).
+func (s *Sanitizer) sanitizeError(fieldName string, err error) (error, bool) {
+	if sanitized, ok := err.(*sanitizedError); ok {
+		return sanitized, true
+	}
+	msg := err.Error()
+	sanitized := s.sanitizeFieldText(fieldName, msg)
+	if s.isRemoved(msg, sanitized) {
+		return nil, false
+	}
+	return &sanitizedError{err: err, msg: sanitized}, true
+}
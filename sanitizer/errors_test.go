@@ -0,0 +1,224 @@
+package sanitizer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var errUserNotFound = errors.New("not found")
+
+// lookupError carries PII in a typed error
+type lookupError struct{ Email string }
+
+func (e *lookupError) Error() string { return "no account for " + e.Email }
+
+func TestSanitizerError(t *testing.T) {
+	s := NewDefault()
+
+	err := s.Error(fmt.Errorf("user %s: %w", "user@example.com", errUserNotFound))
+	if err.Error() != "user [REDACTED]: not found" {
+		t.Errorf("Expected email in message to be redacted, got %q", err.Error())
+	}
+	if !errors.Is(err, errUserNotFound) {
+		t.Error("Expected errors.Is to see the wrapped error")
+	}
+
+	var lookup *lookupError
+	typed := s.Error(fmt.Errorf("lookup: %w", &lookupError{Email: "user@example.com"}))
+	if !errors.As(typed, &lookup) || lookup.Email != "user@example.com" {
+		t.Error("Expected errors.As to reach the original error")
+	}
+	if errors.Unwrap(typed) == nil {
+		t.Error("Expected Unwrap to return the original error")
+	}
+
+	if s.Error(nil) != nil {
+		t.Error("Expected nil error to stay nil")
+	}
+	if s.Error(err) != err {
+		t.Error("Expected sanitized error not to be wrapped twice")
+	}
+	if clean := s.Error(fs.ErrNotExist); clean.Error() != fs.ErrNotExist.Error() {
+		t.Errorf("Expected clean message to be unchanged, got %q", clean.Error())
+	}
+}
+
+func TestSlogHandler_Errors(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := slog.New(NewSlogHandler(slog.NewJSONHandler(&buf, nil), s))
+
+	logger.Error("lookup failed", "error", fmt.Errorf("user %s: %w", "user@example.com", errUserNotFound))
+
+	if !strings.Contains(buf.String(), `"error":"user [REDACTED]: not found"`) {
+		t.Errorf("Expected error message to be sanitized in place, got %s", buf.String())
+	}
+}
+
+func TestRecover(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{"string", "bad input from user@example.com", "panic: bad input from [REDACTED]"},
+		{"error", fmt.Errorf("user %s: %w", "user@example.com", errUserNotFound), "panic: user [REDACTED]: not found"},
+		{"stringer", emailStringer{email: "user@example.com"}, "panic: contact [REDACTED]"},
+		{"other", 42, "panic: 42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recovered *PanicError
+			func() {
+				defer s.Recover(func(p *PanicError) { recovered = p })
+				panic(tt.value)
+			}()
+
+			if recovered == nil {
+				t.Fatal("Expected panic to be recovered")
+			}
+			if recovered.Error() != tt.expected {
+				t.Errorf("got %q, want %q", recovered.Error(), tt.expected)
+			}
+			if !bytes.Contains(recovered.Stack, []byte("TestRecover")) {
+				t.Errorf("Expected stack trace of the panicking goroutine, got %s", recovered.Stack)
+			}
+		})
+	}
+}
+
+func TestRecover_ErrorsIs(t *testing.T) {
+	s := NewDefault()
+
+	var recovered *PanicError
+	func() {
+		defer s.Recover(func(p *PanicError) { recovered = p })
+		panic(fmt.Errorf("user %s: %w", "user@example.com", errUserNotFound))
+	}()
+
+	if !errors.Is(recovered, errUserNotFound) {
+		t.Error("Expected errors.Is to see through the panic value")
+	}
+}
+
+func TestSanitizerGo(t *testing.T) {
+	s := NewDefault()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var recovered *PanicError
+	s.Go(func() {
+		panic("worker failed for +6591234567")
+	}, func(p *PanicError) {
+		recovered = p
+		wg.Done()
+	})
+	wg.Wait()
+
+	if recovered.Error() != "panic: worker failed for [REDACTED]" {
+		t.Errorf("Expected sanitized panic, got %q", recovered.Error())
+	}
+}
+
+func TestRecoverHandler(t *testing.T) {
+	s := NewDefault()
+
+	var recovered *PanicError
+	var path string
+	handler := RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("cannot charge user@example.com")
+	}), s, func(r *http.Request, p *PanicError) {
+		path = r.URL.Path
+		recovered = p
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/charge", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500, got %d", rec.Code)
+	}
+	if path != "/charge" || recovered.Error() != "panic: cannot charge [REDACTED]" {
+		t.Errorf("Expected sanitized panic for /charge, got %q for %q", recovered.Error(), path)
+	}
+}
+
+func TestRecoverHandler_ResponseStarted(t *testing.T) {
+	s := NewDefault()
+
+	handler := RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("partial"))
+		panic("cannot charge user@example.com")
+	}), s, func(*http.Request, *PanicError) {})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/charge", nil))
+
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "partial" {
+		t.Errorf("Expected the started response to be left alone, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	// Flushing sends the headers too
+	handler = RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush()
+		panic("stream failed")
+	}), s, func(*http.Request, *PanicError) {})
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Errorf("Expected the flushed response to be left alone, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRecoverHandler_DefaultLogger(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer slog.SetDefault(previous)
+
+	handler := RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("cannot charge user@example.com")
+	}), s, nil)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/user@example.com", nil))
+
+	if strings.Contains(buf.String(), "user@example.com") {
+		t.Errorf("Expected panic and path to be sanitized, got %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "panic recovered") {
+		t.Errorf("Expected panic to be logged, got %s", buf.String())
+	}
+}
+
+func TestRecoverHandler_ErrAbortHandler(t *testing.T) {
+	s := NewDefault()
+
+	handler := RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}), s, func(*http.Request, *PanicError) {
+		t.Error("Expected ErrAbortHandler not to be reported")
+	})
+
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Errorf("Expected ErrAbortHandler to be re-panicked, got %v", v)
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
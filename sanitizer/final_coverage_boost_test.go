@@ -217,7 +217,7 @@ func TestSanitizeSlice_MaxDepth(t *testing.T) {
 		},
 	}
 
-	result := s.sanitizeSlice(deepSlice, 0)
+	result := s.sanitizeSlice(deepSlice, "", 0)
 
 	if len(result) == 0 {
 		t.Error("Expected non-empty result")
@@ -0,0 +1,419 @@
+package sanitizer
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fmtValue renders a value like fmt does, with PII sanitized
+type fmtValue struct {
+	sanitizer *Sanitizer
+	value     any
+}
+
+// Fmt wraps v so printf-style calls render it like fmt would, with struct fields,
+// map entries and strings sanitized using the same rules as SanitizeStructWithTags
+// (json names and pii tags drive field matching). Supports %v, %+v, %#v, %s and %q;
+// other verbs are applied to the sanitized leaves the way fmt applies them.
+//
+// Unlike SanitizeStructWithTags, unexported fields are printed (as fmt does), sanitized
+// by their field name and content. Nested pointers print as addresses, as with fmt.
+//
+// Example:
+//
+//	s := NewDefault()
+//	log.Printf("request: %+v", s.Fmt(req)) // request: &{Email:[REDACTED] OrderID:ORD-123}
+func (s *Sanitizer) Fmt(v any) fmt.Formatter {
+	return fmtValue{sanitizer: s, value: v}
+}
+
+// Format implements fmt.Formatter
+func (f fmtValue) Format(st fmt.State, verb rune) {
+	p := &fmtPrinter{
+		sanitizer: f.sanitizer,
+		verb:      verb,
+		directive: fmt.FormatString(st, verb),
+		sharpV:    verb == 'v' && st.Flag('#'),
+		plusV:     verb == 'v' && st.Flag('+'),
+	}
+
+	if f.value == nil {
+		fmt.Fprintf(&p.buf, p.directive, nil)
+	} else {
+		p.printValue(reflect.ValueOf(f.value), "", nil, 0)
+	}
+	_, _ = st.Write(p.buf.Bytes())
+}
+
+// fmtPrinter mirrors fmt's printValue, formatting each leaf with the original
+// directive so flags, width and precision behave as they do in fmt
+type fmtPrinter struct {
+	sanitizer *Sanitizer
+	buf       bytes.Buffer
+	verb      rune
+	directive string
+	sharpV    bool
+	plusV     bool
+}
+
+// printValue writes a value. name drives field name matching for strings and
+// tag carries the pii struct tag of the field being printed, if any.
+func (p *fmtPrinter) printValue(val reflect.Value, name string, tag *piiTag, depth int) {
+	if depth > p.sanitizer.config.MaxDepth {
+		p.buf.WriteString("...")
+		return
+	}
+
+	if tag != nil && tag.action == "redact" {
+		for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+			if val.IsNil() {
+				p.printLeaf(nil)
+				return
+			}
+			val = val.Elem()
+		}
+		if val.Kind() == reflect.String {
+			p.printLeaf(p.sanitizer.redact(val.String()))
+		} else {
+			p.printLeaf("[REDACTED]")
+		}
+		return
+	}
+	preserve := tag != nil && tag.action == "preserve"
+
+	if val.IsValid() && val.CanInterface() && p.handleMethods(val, name, preserve, depth) {
+		return
+	}
+
+	switch val.Kind() {
+	case reflect.Invalid:
+		if depth == 0 || p.verb == 'v' {
+			p.buf.WriteString("<nil>")
+		} else {
+			p.badVerb("<nil>")
+		}
+
+	case reflect.Bool:
+		p.printLeaf(val.Bool())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		p.printLeaf(nativeNumber(val))
+
+	case reflect.Complex64, reflect.Complex128:
+		p.printLeaf(val.Complex())
+
+	case reflect.String:
+		p.printString(val.String(), name, preserve)
+
+	case reflect.Map:
+		p.printMap(val, depth)
+
+	case reflect.Struct:
+		p.printStruct(val, depth)
+
+	case reflect.Interface:
+		elem := val.Elem()
+		if !elem.IsValid() {
+			if p.sharpV {
+				p.buf.WriteString(val.Type().String() + "(nil)")
+			} else {
+				p.buf.WriteString("<nil>")
+			}
+			return
+		}
+		p.printValue(elem, name, tag, depth+1)
+
+	case reflect.Array, reflect.Slice:
+		p.printList(val, name, preserve, depth)
+
+	case reflect.Ptr:
+		// Only top-level pointers to composites are followed, as in fmt
+		if depth == 0 && !val.IsNil() {
+			switch val.Elem().Kind() {
+			case reflect.Array, reflect.Slice, reflect.Struct, reflect.Map:
+				p.buf.WriteByte('&')
+				p.printValue(val.Elem(), name, tag, depth+1)
+				return
+			}
+		}
+		p.printPointer(val)
+
+	default:
+		// Channels, functions and unsafe pointers
+		p.printPointer(val)
+	}
+}
+
+// handleMethods sanitizes the output of Formatter, GoStringer, error and Stringer
+// implementations the way fmt would call them. Returns true if the value was printed.
+func (p *fmtPrinter) handleMethods(val reflect.Value, name string, preserve bool, depth int) bool {
+	if val.Kind() == reflect.Ptr && val.IsNil() {
+		// A nil pointer holds no data, so fmt prints it: <nil> for %v, or for %s and %q
+		// when a String or Error method panics on the nil receiver, %!s(*T=<nil>) otherwise
+		p.buf.WriteString(p.sanitizer.sanitizeText(safeSprintf(p.directive, val.Interface())))
+		return true
+	}
+
+	switch v := val.Interface().(type) {
+	case fmtValue:
+		// A nested Fmt value is printed in place rather than formatted twice
+		p.printValue(reflect.ValueOf(v.value), name, nil, depth)
+		return true
+
+	case fmt.Formatter:
+		p.buf.WriteString(p.sanitizer.sanitizeText(safeSprintf(p.directive, v)))
+		return true
+	}
+
+	if p.sharpV {
+		if g, ok := val.Interface().(fmt.GoStringer); ok {
+			p.buf.WriteString(p.sanitizer.sanitizeText(safeGoString(g)))
+			return true
+		}
+		return false
+	}
+
+	switch p.verb {
+	case 'v', 's', 'x', 'X', 'q':
+	default:
+		return false
+	}
+
+	switch v := val.Interface().(type) {
+	case error:
+		msg := v.Error()
+		if !preserve {
+			msg = p.sanitizer.sanitizeFieldText(name, msg)
+		}
+		p.printLeaf(msg)
+		return true
+
+	case fmt.Stringer:
+		p.printString(safeStringer(v), name, preserve)
+		return true
+	}
+	return false
+}
+
+// printString writes a sanitized string leaf
+func (p *fmtPrinter) printString(str, name string, preserve bool) {
+	if !preserve {
+		str = p.sanitizer.SanitizeField(name, str)
+	}
+	p.printLeaf(str)
+}
+
+// printLeaf formats a scalar with the original directive
+func (p *fmtPrinter) printLeaf(v any) {
+	fmt.Fprintf(&p.buf, p.directive, v)
+}
+
+// printMap writes map entries sorted by key, as fmt does
+func (p *fmtPrinter) printMap(val reflect.Value, depth int) {
+	if p.sharpV {
+		p.buf.WriteString(val.Type().String())
+		if val.IsNil() {
+			p.buf.WriteString("(nil)")
+			return
+		}
+		p.buf.WriteByte('{')
+	} else {
+		p.buf.WriteString("map[")
+	}
+
+	keys := val.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return fmtKeyLess(keys[i], keys[j]) })
+	for i, key := range keys {
+		if i > 0 {
+			p.writeSeparator()
+		}
+		p.printValue(key, "", nil, depth+1)
+		p.buf.WriteByte(':')
+
+		name := ""
+		if key.Kind() == reflect.String {
+			name = key.String()
+		}
+		p.printValue(val.MapIndex(key), name, nil, depth+1)
+	}
+
+	if p.sharpV {
+		p.buf.WriteByte('}')
+	} else {
+		p.buf.WriteByte(']')
+	}
+}
+
+// printStruct writes struct fields in declaration order, matching on json names and pii tags
+func (p *fmtPrinter) printStruct(val reflect.Value, depth int) {
+	typ := val.Type()
+	if p.sharpV {
+		p.buf.WriteString(typ.String())
+	}
+	p.buf.WriteByte('{')
+
+	for i := 0; i < val.NumField(); i++ {
+		if i > 0 {
+			p.writeSeparator()
+		}
+		fieldType := typ.Field(i)
+		if p.plusV || p.sharpV {
+			p.buf.WriteString(fieldType.Name)
+			p.buf.WriteByte(':')
+		}
+
+		name := fieldType.Name
+		if tagName := strings.Split(fieldType.Tag.Get("json"), ",")[0]; tagName != "" && tagName != "-" {
+			name = tagName
+		}
+
+		field := val.Field(i)
+		if field.Kind() == reflect.Interface && !field.IsNil() {
+			field = field.Elem()
+		}
+		p.printValue(field, name, parsePIITag(fieldType.Tag.Get(piiTagName)), depth+1)
+	}
+
+	p.buf.WriteByte('}')
+}
+
+// printList writes an array or slice. Byte slices are treated as text and sanitized
+// as a whole, so their contents cannot leak through %v's numeric rendering.
+func (p *fmtPrinter) printList(val reflect.Value, name string, preserve bool, depth int) {
+	if val.Type().Elem().Kind() == reflect.Uint8 {
+		data := make([]byte, val.Len())
+		for i := range data {
+			data[i] = byte(val.Index(i).Uint())
+		}
+		if !preserve {
+			data = []byte(p.sanitizer.SanitizeField(name, string(data)))
+		}
+		switch p.verb {
+		case 's', 'q', 'x', 'X':
+			p.printLeaf(data)
+			return
+		}
+		if p.sharpV && val.Kind() == reflect.Slice && val.IsNil() {
+			p.buf.WriteString(val.Type().String() + "(nil)")
+			return
+		}
+		val = reflect.ValueOf(data)
+	}
+
+	if p.sharpV {
+		p.buf.WriteString(val.Type().String())
+		if val.Kind() == reflect.Slice && val.IsNil() {
+			p.buf.WriteString("(nil)")
+			return
+		}
+		p.buf.WriteByte('{')
+	} else {
+		p.buf.WriteByte('[')
+	}
+
+	for i := 0; i < val.Len(); i++ {
+		if i > 0 {
+			p.writeSeparator()
+		}
+		p.printValue(val.Index(i), "", nil, depth+1)
+	}
+
+	if p.sharpV {
+		p.buf.WriteByte('}')
+	} else {
+		p.buf.WriteByte(']')
+	}
+}
+
+// printPointer writes a pointer, channel or function as an address, as fmt does
+func (p *fmtPrinter) printPointer(val reflect.Value) {
+	u := val.Pointer()
+	if u == 0 && val.CanInterface() {
+		// A nil value holds no data; let fmt apply the flags
+		p.buf.WriteString(fmt.Sprintf(p.directive, val.Interface()))
+		return
+	}
+	switch p.verb {
+	case 'v':
+		switch {
+		case p.sharpV:
+			p.buf.WriteString("(" + val.Type().String() + ")(")
+			if u == 0 {
+				p.buf.WriteString("nil")
+			} else {
+				p.buf.WriteString("0x" + strconv.FormatUint(uint64(u), 16))
+			}
+			p.buf.WriteByte(')')
+		case u == 0:
+			p.buf.WriteString("<nil>")
+		default:
+			p.buf.WriteString("0x" + strconv.FormatUint(uint64(u), 16))
+		}
+	case 'p', 'b', 'o', 'd', 'x', 'X':
+		p.printLeaf(u)
+	default:
+		if u == 0 {
+			p.badVerb(val.Type().String() + "=<nil>")
+		} else {
+			p.badVerb(val.Type().String() + "=0x" + strconv.FormatUint(uint64(u), 16))
+		}
+	}
+}
+
+// writeSeparator writes the element separator for the current verb
+func (p *fmtPrinter) writeSeparator() {
+	if p.sharpV {
+		p.buf.WriteString(", ")
+	} else {
+		p.buf.WriteByte(' ')
+	}
+}
+
+// badVerb writes fmt's error marker for an unsupported verb
+func (p *fmtPrinter) badVerb(detail string) {
+	p.buf.WriteString("%!" + string(p.verb) + "(" + detail + ")")
+}
+
+// fmtKeyLess orders map keys like fmt: numbers numerically, strings lexically, others by text
+func fmtKeyLess(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	default:
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	}
+}
+
+// safeSprintf formats v, recovering from panics in its Format method the way fmt does
+func safeSprintf(directive string, v any) (result string) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = fmt.Sprintf("<PANIC=%v>", r)
+		}
+	}()
+	return fmt.Sprintf(directive, v)
+}
+
+// safeGoString calls GoString, recovering from panics the way fmt does
+func safeGoString(g fmt.GoStringer) (result string) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = fmt.Sprintf("<PANIC=%v>", r)
+		}
+	}()
+	return g.GoString()
+}
@@ -0,0 +1,173 @@
+package sanitizer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type fmtItem struct {
+	Label string
+	Ref   string `json:"ref"`
+}
+
+type fmtRequest struct {
+	Email    string `json:"email"`
+	OrderID  string `json:"orderId"`
+	Card     string `pii:"redact"`
+	Notes    string `pii:"preserve"`
+	Quantity int
+	Tags     []string
+	Meta     map[string]any
+	Item     fmtItem
+	Err      error
+	note     string
+}
+
+func TestFmt_Verbs(t *testing.T) {
+	s := NewDefault()
+
+	req := &fmtRequest{
+		Email:    "user@example.com",
+		OrderID:  "ORD-123",
+		Card:     "4111",
+		Notes:    "user@example.com",
+		Quantity: 2,
+		Tags:     []string{"vip", "a@b.com"},
+		Meta:     map[string]any{"phone": "+6591234567", "count": 1},
+		Item:     fmtItem{Label: "Gift", Ref: "ORD-9"},
+		Err:      fmt.Errorf("lookup %s: %w", "user@example.com", errUserNotFound),
+		note:     "call +6591234567",
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: "%v",
+			expected: "&{[REDACTED] ORD-123 [REDACTED] user@example.com 2 [vip [REDACTED]] " +
+				"map[count:1 phone:[REDACTED]] {Gift ORD-9} lookup [REDACTED]: not found [REDACTED]}",
+		},
+		{
+			format: "%+v",
+			expected: "&{Email:[REDACTED] OrderID:ORD-123 Card:[REDACTED] Notes:user@example.com Quantity:2 " +
+				"Tags:[vip [REDACTED]] Meta:map[count:1 phone:[REDACTED]] Item:{Label:Gift Ref:ORD-9} " +
+				"Err:lookup [REDACTED]: not found note:[REDACTED]}",
+		},
+		{
+			format: "%s",
+			expected: "&{[REDACTED] ORD-123 [REDACTED] user@example.com %!s(int=2) [vip [REDACTED]] " +
+				"map[count:%!s(int=1) phone:[REDACTED]] {Gift ORD-9} lookup [REDACTED]: not found [REDACTED]}",
+		},
+		{
+			format: "%q",
+			expected: `&{"[REDACTED]" "ORD-123" "[REDACTED]" "user@example.com" '\x02' ["vip" "[REDACTED]"] ` +
+				`map["count":'\x01' "phone":"[REDACTED]"] {"Gift" "ORD-9"} "lookup [REDACTED]: not found" "[REDACTED]"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, s.Fmt(req)); got != tt.expected {
+				t.Errorf("got:  %s\nwant: %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFmt_GoSyntax(t *testing.T) {
+	s := NewDefault()
+
+	value := fmtItem{Label: "Gift", Ref: "user@example.com"}
+	expected := `sanitizer.fmtItem{Label:"Gift", Ref:"[REDACTED]"}`
+	if got := fmt.Sprintf("%#v", s.Fmt(value)); got != expected {
+		t.Errorf("got %s, want %s", got, expected)
+	}
+
+	m := map[string][]string{"emails": {"user@example.com"}}
+	expected = `map[string][]string{"emails":[]string{"[REDACTED]"}}`
+	if got := fmt.Sprintf("%#v", s.Fmt(m)); got != expected {
+		t.Errorf("got %s, want %s", got, expected)
+	}
+}
+
+func TestFmt_MatchesFmtWithoutPII(t *testing.T) {
+	s := NewDefault()
+
+	var nilSlice []int
+	var nilMap map[string]int
+	var nilErr error
+	var nilItem *fmtItem
+	var nilStringer *emailStringer
+	var nilChan chan int
+	values := []any{
+		"plain text",
+		42,
+		3.14,
+		true,
+		nil,
+		nilErr,
+		nilSlice,
+		nilMap,
+		[]int{3, 1, 2},
+		[2]bool{true, false},
+		map[int]string{2: "b", 1: "a"},
+		fmtItem{Label: "Gift", Ref: "ORD-9"},
+		&fmtItem{Label: "Box"},
+		[]any{"x", 1, nil, fmtItem{}},
+		nilItem,
+		nilStringer,
+		nilChan,
+		struct{ Item *fmtItem }{},
+		struct {
+			D   time.Duration
+			Err error
+			B   []byte
+		}{time.Second, errors.New("timeout"), []byte("raw")},
+	}
+	formats := []string{"%v", "%+v", "%#v", "%s", "%q", "%d", "%x", "%8v", "%-6s"}
+
+	for _, value := range values {
+		for _, format := range formats {
+			want := fmt.Sprintf(format, value)
+			if got := fmt.Sprintf(format, s.Fmt(value)); got != want {
+				t.Errorf("Sprintf(%q, %#v):\n got: %s\nwant: %s", format, value, got, want)
+			}
+		}
+	}
+}
+
+func TestFmt_Strings(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		format   string
+		value    any
+		expected string
+	}{
+		{"%s", "user@example.com", "[REDACTED]"},
+		{"%q", "user@example.com", `"[REDACTED]"`},
+		{"%v", emailStringer{email: "user@example.com"}, "[REDACTED]"},
+		{"%v", errors.New("no account for user@example.com"), "no account for [REDACTED]"},
+		{"%s", []byte("user@example.com"), "[REDACTED]"},
+		{"%v", []any{s.Fmt("user@example.com")}, "[[REDACTED]]"},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, s.Fmt(tt.value)); got != tt.expected {
+			t.Errorf("Sprintf(%q, %v) = %s, want %s", tt.format, tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestFmt_HashStrategy(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyHash))
+
+	got := fmt.Sprintf("%+v", s.Fmt(fmtItem{Label: "Gift", Ref: "user@example.com"}))
+	expected := "{Label:Gift Ref:" + s.SanitizeField("ref", "user@example.com") + "}"
+	if got != expected {
+		t.Errorf("got %s, want %s", got, expected)
+	}
+}
@@ -0,0 +1,39 @@
+package sanitizer
+
+import (
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// kitLogger sanitizes key/value pairs before passing them to the wrapped go-kit logger
+type kitLogger struct {
+	next      log.Logger
+	sanitizer *Sanitizer
+}
+
+// WrapKitLogger returns a go-kit log.Logger that sanitizes every key/value pair
+// before delegating to logger. Each key drives field name matching for its value,
+// consistent with the slog, zap and zerolog adapters. Level values from the
+// go-kit level package are passed through so level.NewFilter keeps working.
+//
+// Contextual pairs added with log.With are only sanitized when With is applied
+// to the wrapped logger, so wrap first:
+//
+//	s := NewDefault()
+//	logger := WrapKitLogger(log.NewJSONLogger(os.Stdout), s)
+//	logger = log.With(logger, "ts", log.DefaultTimestampUTC)
+//	logger.Log("email", "user@example.com") // "email":"[REDACTED]"
+func WrapKitLogger(logger log.Logger, s *Sanitizer) log.Logger {
+	return &kitLogger{next: logger, sanitizer: s}
+}
+
+// Log implements log.Logger
+func (l *kitLogger) Log(keyvals ...any) error {
+	return l.next.Log(l.sanitizer.sanitizeKeyvals(keyvals, isKitLevel)...)
+}
+
+// isKitLevel reports whether v is a go-kit level value
+func isKitLevel(v any) bool {
+	_, ok := v.(level.Value)
+	return ok
+}
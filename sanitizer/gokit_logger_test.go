@@ -0,0 +1,252 @@
+package sanitizer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// newTestKitLogger returns a sanitizing go-kit JSON logger writing to buf
+func newTestKitLogger(s *Sanitizer, buf *bytes.Buffer) log.Logger {
+	return WrapKitLogger(log.NewJSONLogger(buf), s)
+}
+
+// decodeKit parses a go-kit JSON line
+func decodeKit(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse log output: %v\n%s", err, buf.String())
+	}
+	return entry
+}
+
+func TestKitLoggerIntegration(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := newTestKitLogger(s, &buf)
+
+	if err := logger.Log(
+		"msg", "user action",
+		"email", "user@example.com",
+		"fullName", "John Doe",
+		"orderId", "ORD-123",
+		"amount", 100.50,
+	); err != nil {
+		t.Fatalf("Log() error = %v", err)
+	}
+
+	entry := decodeKit(t, &buf)
+	if entry["email"] != "[REDACTED]" {
+		t.Errorf("Expected email to be redacted, got %v", entry["email"])
+	}
+	if entry["fullName"] != "[REDACTED]" {
+		t.Errorf("Expected name to be redacted, got %v", entry["fullName"])
+	}
+	if entry["orderId"] != "ORD-123" {
+		t.Errorf("Expected orderId to be preserved, got %v", entry["orderId"])
+	}
+	if entry["amount"] != 100.50 {
+		t.Errorf("Expected amount to be preserved, got %v", entry["amount"])
+	}
+}
+
+func TestKitLoggerNested(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := newTestKitLogger(s, &buf)
+
+	_ = logger.Log("order", map[string]any{
+		"orderId": "ORD-123",
+		"customer": map[string]any{
+			"email": "user@example.com",
+			"phone": "+6591234567",
+		},
+	})
+
+	order := decodeKit(t, &buf)["order"].(map[string]any)
+	customer := order["customer"].(map[string]any)
+	if customer["email"] != "[REDACTED]" || customer["phone"] != "[REDACTED]" {
+		t.Errorf("Expected nested PII to be redacted, got %v", customer)
+	}
+	if order["orderId"] != "ORD-123" {
+		t.Errorf("Expected orderId to be preserved, got %v", order["orderId"])
+	}
+}
+
+func TestKitLoggerSlice(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := newTestKitLogger(s, &buf)
+
+	_ = logger.Log("contacts", []string{"user@example.com", "not-an-email"})
+
+	contacts := decodeKit(t, &buf)["contacts"].([]any)
+	if contacts[0] != "[REDACTED]" || contacts[1] != "not-an-email" {
+		t.Errorf("Expected slice to be sanitized by content, got %v", contacts)
+	}
+}
+
+func TestKitLoggerStruct(t *testing.T) {
+	s := NewDefault()
+
+	type User struct {
+		Email   string `json:"email"`
+		OrderID string `json:"orderId"`
+	}
+
+	var buf bytes.Buffer
+	logger := newTestKitLogger(s, &buf)
+
+	_ = logger.Log("user", User{Email: "user@example.com", OrderID: "ORD-123"})
+
+	user := decodeKit(t, &buf)["user"].(map[string]any)
+	if user["email"] != "[REDACTED]" {
+		t.Errorf("Expected email to be redacted, got %v", user["email"])
+	}
+	if user["orderId"] != "ORD-123" {
+		t.Errorf("Expected orderId to be preserved, got %v", user["orderId"])
+	}
+}
+
+func TestKitLoggerMixedTypes(t *testing.T) {
+	s := NewDefault()
+
+	var keyvals []any
+	logger := WrapKitLogger(log.LoggerFunc(func(kv ...any) error {
+		keyvals = kv
+		return nil
+	}), s)
+
+	_ = logger.Log(
+		"string", "user@example.com",
+		"int", 12345,
+		"float", 99.99,
+		"bool", true,
+		"null", nil,
+		"err", errors.New("no account for user@example.com"),
+		"dangling",
+	)
+
+	expected := []any{
+		"string", "[REDACTED]",
+		"int", 12345,
+		"float", 99.99,
+		"bool", true,
+		"null", nil,
+		"err", "no account for [REDACTED]",
+		"dangling",
+	}
+	if len(keyvals) != len(expected) {
+		t.Fatalf("Expected %d keyvals, got %v", len(expected), keyvals)
+	}
+	if err, ok := keyvals[11].(error); ok {
+		// Errors stay errors so loggers can format and unwrap them
+		keyvals[11] = err.Error()
+	} else {
+		t.Errorf("Expected error value to stay an error, got %T", keyvals[11])
+	}
+	for i := range expected {
+		if keyvals[i] != expected[i] {
+			t.Errorf("keyvals[%d]: expected %#v, got %#v", i, expected[i], keyvals[i])
+		}
+	}
+}
+
+func TestKitLoggerWith(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := log.With(newTestKitLogger(s, &buf), "email", "user@example.com", "ts", log.DefaultTimestampUTC)
+
+	_ = logger.Log("orderId", "ORD-123")
+
+	entry := decodeKit(t, &buf)
+	if entry["email"] != "[REDACTED]" {
+		t.Errorf("Expected context email to be redacted, got %v", entry["email"])
+	}
+	if ts, _ := entry["ts"].(string); ts == "" || ts == "[REDACTED]" {
+		t.Errorf("Expected timestamp to be preserved, got %v", entry["ts"])
+	}
+	if entry["orderId"] != "ORD-123" {
+		t.Errorf("Expected orderId to be preserved, got %v", entry["orderId"])
+	}
+}
+
+func TestKitLoggerLevelFilter(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := WrapKitLogger(level.NewFilter(log.NewJSONLogger(&buf), level.AllowInfo()), s)
+
+	_ = level.Debug(logger).Log("email", "user@example.com")
+	if buf.Len() != 0 {
+		t.Errorf("Expected debug record to be filtered, got %s", buf.String())
+	}
+
+	_ = level.Info(logger).Log("email", "user@example.com")
+	entry := decodeKit(t, &buf)
+	if entry["level"] != "info" || entry["email"] != "[REDACTED]" {
+		t.Errorf("Expected info record with redacted email, got %v", entry)
+	}
+}
+
+func TestKitLoggerRemoveStrategy(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyRemove))
+
+	var buf bytes.Buffer
+	logger := newTestKitLogger(s, &buf)
+
+	_ = logger.Log("email", "user@example.com", "orderId", "ORD-1")
+
+	entry := decodeKit(t, &buf)
+	if _, exists := entry["email"]; exists {
+		t.Errorf("Expected email pair to be removed, got %v", entry)
+	}
+	if entry["orderId"] != "ORD-1" {
+		t.Errorf("Expected orderId to be preserved, got %v", entry["orderId"])
+	}
+}
+
+func TestKitLoggerRegionalPatterns(t *testing.T) {
+	s := NewForRegion(Singapore, Malaysia, UAE)
+
+	var buf bytes.Buffer
+	logger := newTestKitLogger(s, &buf)
+
+	_ = logger.Log(
+		"nric", "S1234567D",
+		"mykad", "901230-14-5678",
+		"iban", "AE07 0331 2345 6789 0123 456",
+	)
+
+	for _, value := range []string{"S1234567D", "901230-14-5678", "AE07 0331 2345 6789 0123 456"} {
+		if strings.Contains(buf.String(), value) {
+			t.Errorf("Expected %s to be redacted, got %s", value, buf.String())
+		}
+	}
+}
+
+func TestKitLoggerPartialMasking(t *testing.T) {
+	config := NewDefaultConfig().
+		WithStrategy(StrategyPartial).
+		WithPartialMasking('*', 0, 4)
+	s := New(config)
+
+	var buf bytes.Buffer
+	logger := newTestKitLogger(s, &buf)
+
+	_ = logger.Log("creditCard", "4532015112830366")
+
+	if entry := decodeKit(t, &buf); entry["creditCard"] != "************0366" {
+		t.Errorf("Expected partial masking, got %v", entry["creditCard"])
+	}
+}
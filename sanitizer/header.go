@@ -0,0 +1,229 @@
+package sanitizer
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// credentialNameParts mark header and cookie names that carry credentials. A name
+// matches if one of its segments (split on '-', '_', '.' and camelCase) is listed.
+var credentialNameParts = map[string]bool{
+	"auth": true, "authorization": true, "token": true, "sess": true, "session": true,
+	"sessionid": true, "sid": true, "phpsessid": true, "jsessionid": true, "secret": true,
+	"password": true, "passwd": true, "pwd": true, "key": true, "apikey": true, "jwt": true,
+	"csrf": true, "xsrf": true, "csrftoken": true, "xsrftoken": true, "signature": true,
+	"credential": true, "credentials": true,
+}
+
+// urlHeaders hold URLs and are sanitized with SanitizeURL
+var urlHeaders = map[string]bool{
+	"Referer":          true,
+	"Location":         true,
+	"Content-Location": true,
+	"Origin":           true,
+}
+
+// jwtPattern matches JSON Web Tokens: base64url JSON header and payload plus signature
+var jwtPattern = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.(eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*)`)
+
+// SanitizeHeader returns a sanitized copy of h that understands header semantics:
+//   - Authorization and Proxy-Authorization keep their scheme (Bearer [REDACTED])
+//   - Cookie and Set-Cookie values are sanitized per cookie, by cookie name;
+//     Set-Cookie attributes such as Path and HttpOnly are kept
+//   - headers named like credentials (X-Api-Key, X-Auth-Token, X-Csrf-Token) are redacted
+//   - Referer, Location, Content-Location and Origin are sanitized with SanitizeURL
+//   - other headers are matched by name (X-User-Email as user_email) and content,
+//     and JWT payloads and signatures in their values are redacted
+//
+// Credentials (Authorization values, credential headers and cookies, and JWT payloads
+// and signatures) are always written as [REDACTED] whatever the strategy, so that no
+// part of a token is logged.
+//
+// Under StrategyRemove removed values are dropped, and headers without remaining
+// values are omitted.
+func (s *Sanitizer) SanitizeHeader(h http.Header) http.Header {
+	result := make(http.Header, len(h))
+	for name, values := range h {
+		for _, value := range values {
+			sanitized := s.sanitizeHeaderValue(name, value)
+			if !s.isRemoved(value, sanitized) {
+				result[name] = append(result[name], sanitized)
+			}
+		}
+	}
+	return result
+}
+
+// sanitizeHeaderValue sanitizes a single header value by header semantics
+func (s *Sanitizer) sanitizeHeaderValue(name, value string) string {
+	canonical := http.CanonicalHeaderKey(name)
+	switch {
+	case canonical == "Authorization" || canonical == "Proxy-Authorization":
+		return s.sanitizeCredentials(value)
+	case canonical == "Cookie":
+		return s.sanitizeCookies(value)
+	case canonical == "Set-Cookie":
+		return s.sanitizeSetCookie(value)
+	case urlHeaders[canonical]:
+		return s.SanitizeURL(value)
+	case s.isCredentialName(canonical):
+		return s.redactCredential(value)
+	}
+
+	sanitized := s.SanitizeField(canonical, value)
+	if sanitized == value && !s.explicitSafe[strings.ToLower(canonical)] {
+		// X-User-Email is matched as user_email
+		sanitized = s.SanitizeField(headerFieldName(canonical), value)
+	}
+	if sanitized == value {
+		sanitized = s.redactJWTs(value)
+	}
+	return sanitized
+}
+
+// sanitizeCredentials redacts an Authorization value, keeping its scheme
+func (s *Sanitizer) sanitizeCredentials(value string) string {
+	scheme, _, found := strings.Cut(strings.TrimSpace(value), " ")
+	redacted := s.redactCredential(value)
+	if !found || redacted == "" {
+		return redacted
+	}
+	return scheme + " " + redacted
+}
+
+// redactCredential redacts a credential as [REDACTED] whatever the strategy, since
+// partial masks and hashes of tokens still leak them. Under StrategyRemove it returns
+// "" so the value is removed.
+func (s *Sanitizer) redactCredential(value string) string {
+	if s.config.Strategy == StrategyRemove {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// sanitizeCookies sanitizes a Cookie header (name=value pairs separated by ';')
+func (s *Sanitizer) sanitizeCookies(value string) string {
+	cookies := strings.Split(value, ";")
+	kept := cookies[:0]
+	for _, cookie := range cookies {
+		sanitized, keep := s.sanitizeCookie(cookie)
+		if !keep {
+			continue
+		}
+		if len(kept) == 0 {
+			// The first cookie may have been dropped along with its separator
+			sanitized = strings.TrimLeft(sanitized, " ")
+		}
+		kept = append(kept, sanitized)
+	}
+	return strings.Join(kept, ";")
+}
+
+// sanitizeSetCookie sanitizes the cookie in a Set-Cookie header, keeping its attributes
+func (s *Sanitizer) sanitizeSetCookie(value string) string {
+	cookie, attrs, hasAttrs := strings.Cut(value, ";")
+	sanitized, keep := s.sanitizeCookie(cookie)
+	if !keep {
+		return ""
+	}
+	if hasAttrs {
+		return sanitized + ";" + attrs
+	}
+	return sanitized
+}
+
+// sanitizeCookie sanitizes a name=value cookie pair, keeping the whitespace around it.
+// Returns false if the cookie should be dropped (StrategyRemove).
+func (s *Sanitizer) sanitizeCookie(cookie string) (string, bool) {
+	trimmed := strings.TrimSpace(cookie)
+	name, value, found := strings.Cut(trimmed, "=")
+	if !found || value == "" {
+		return cookie, true
+	}
+
+	var sanitized string
+	if s.isCredentialName(name) {
+		sanitized = s.redactCredential(value)
+	} else {
+		// Cookie values are often URL-encoded (john%40acme.com)
+		decoded, err := url.QueryUnescape(value)
+		if err != nil {
+			decoded = value
+		}
+		sanitized = s.SanitizeField(name, decoded)
+		if sanitized == decoded {
+			sanitized = s.redactJWTs(value)
+		}
+	}
+	if sanitized == value {
+		return cookie, true
+	}
+	if s.isRemoved(value, sanitized) {
+		return "", false
+	}
+
+	lead := cookie[:strings.Index(cookie, trimmed)]
+	return lead + name + "=" + sanitized, true
+}
+
+// redactJWTs redacts the payload and signature of JWTs in value, keeping the
+// header that names the algorithm and key
+func (s *Sanitizer) redactJWTs(value string) string {
+	if !strings.Contains(value, "eyJ") {
+		return value
+	}
+	return jwtPattern.ReplaceAllStringFunc(value, func(token string) string {
+		header, rest, _ := strings.Cut(token, ".")
+		if redacted := s.redactCredential(rest); redacted != "" {
+			return header + "." + redacted
+		}
+		return header
+	})
+}
+
+// isCredentialName reports whether a header or cookie name denotes a credential
+func (s *Sanitizer) isCredentialName(name string) bool {
+	lower := strings.ToLower(name)
+	if s.explicitSafe[lower] {
+		return false
+	}
+	for _, segment := range nameSegments(name) {
+		if credentialNameParts[segment] {
+			return true
+		}
+	}
+	return false
+}
+
+// nameSegments splits a header or cookie name into lowercase segments on '-', '_',
+// '.' and camelCase boundaries: X-Api-Key, x_api_key and xApiKey give x, api, key
+func nameSegments(name string) []string {
+	var segments []string
+	start := 0
+	for i, r := range name {
+		switch {
+		case r == '-' || r == '_' || r == '.':
+			if i > start {
+				segments = append(segments, strings.ToLower(name[start:i]))
+			}
+			start = i + 1
+		case unicode.IsUpper(r) && i > start && unicode.IsLower(rune(name[i-1])):
+			segments = append(segments, strings.ToLower(name[start:i]))
+			start = i
+		}
+	}
+	if start < len(name) {
+		segments = append(segments, strings.ToLower(name[start:]))
+	}
+	return segments
+}
+
+// headerFieldName converts a header name to a field name: X-User-Email becomes user_email
+func headerFieldName(name string) string {
+	lower := strings.ToLower(name)
+	lower = strings.TrimPrefix(lower, "x-")
+	return strings.ReplaceAll(lower, "-", "_")
+}
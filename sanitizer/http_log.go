@@ -0,0 +1,419 @@
+package sanitizer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultMaxBodySize is the number of body bytes captured for logging when
+// HTTPLogOptions.MaxBodySize is zero
+const DefaultMaxBodySize = 4 << 10
+
+// DefaultHTTPLogHeaders are the headers logged when HTTPLogOptions.Headers is nil
+var DefaultHTTPLogHeaders = []string{
+	"Content-Type",
+	"Content-Length",
+	"User-Agent",
+	"Referer",
+	"X-Request-Id",
+}
+
+// HTTPSink receives sanitized HTTP records
+type HTTPSink func(ctx context.Context, rec *HTTPRecord)
+
+// HTTPLogOptions configures HTTP request logging
+type HTTPLogOptions struct {
+	// Headers lists the request and response headers to log.
+	// Defaults to DefaultHTTPLogHeaders; use an empty slice to log no headers.
+	Headers []string
+
+	// MaxBodySize caps the bytes of each body captured for logging.
+	// Zero uses DefaultMaxBodySize; a negative value disables body logging.
+	MaxBodySize int
+
+	// Sink receives each sanitized record. Defaults to SlogHTTPSink(slog.Default()).
+	Sink HTTPSink
+}
+
+// HTTPRecord is a sanitized HTTP request and response
+type HTTPRecord struct {
+	Method   string
+	URL      string
+	Status   int
+	Duration time.Duration
+
+	RequestHeader  http.Header
+	RequestBody    HTTPBody
+	ResponseHeader http.Header
+	ResponseBody   HTTPBody
+
+	// Error is the sanitized error of a failed outbound request
+	Error string
+}
+
+// HTTPBody is a sanitized, size-capped HTTP body
+type HTTPBody struct {
+	// Content is the sanitized body text. JSON, XML, form-urlencoded, multipart
+	// and text bodies are sanitized by format; other content types are left empty.
+	Content string
+
+	// Size is the body size in bytes, or -1 if unknown
+	Size int64
+
+	// Truncated reports whether the body exceeded the capture limit
+	Truncated bool
+}
+
+// LogValue implements slog.LogValuer
+func (r *HTTPRecord) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("method", r.Method),
+		slog.String("url", r.URL),
+	}
+	if r.Status != 0 {
+		attrs = append(attrs, slog.Int("status", r.Status))
+	}
+	attrs = append(attrs,
+		slog.Duration("duration", r.Duration),
+		slog.Attr{Key: "request", Value: httpMessageValue(r.RequestHeader, r.RequestBody)},
+	)
+	if r.Status != 0 {
+		attrs = append(attrs, slog.Attr{Key: "response", Value: httpMessageValue(r.ResponseHeader, r.ResponseBody)})
+	}
+	if r.Error != "" {
+		attrs = append(attrs, slog.String("error", r.Error))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// httpMessageValue groups the headers and body of a request or response
+func httpMessageValue(header http.Header, body HTTPBody) slog.Value {
+	var attrs []slog.Attr
+	if len(header) > 0 {
+		names := make([]string, 0, len(header))
+		for name := range header {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		headers := make([]slog.Attr, 0, len(names))
+		for _, name := range names {
+			headers = append(headers, slog.String(name, strings.Join(header[name], ", ")))
+		}
+		attrs = append(attrs, slog.Attr{Key: "headers", Value: slog.GroupValue(headers...)})
+	}
+	if body.Content != "" {
+		attrs = append(attrs, slog.String("body", body.Content))
+	}
+	if body.Size >= 0 {
+		attrs = append(attrs, slog.Int64("size", body.Size))
+	}
+	if body.Truncated {
+		attrs = append(attrs, slog.Bool("truncated", true))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// SlogHTTPSink returns an HTTPSink that logs records to logger as an "http" group,
+// at error level for 5xx responses and failed requests and at info level otherwise
+func SlogHTTPSink(logger *slog.Logger) HTTPSink {
+	return func(ctx context.Context, rec *HTTPRecord) {
+		level := slog.LevelInfo
+		if rec.Status == 0 || rec.Status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.LogAttrs(ctx, level, "http request", slog.Any("http", rec))
+	}
+}
+
+// AccessLogHandler returns HTTP middleware that logs every request and response with
+// PII redacted: the method, URL (see SanitizeURL), the headers selected by opts
+// (see SanitizeHeader) and size-capped bodies. Request bodies are captured before next
+// runs and replayed to it unchanged; response bodies are captured as they are written.
+//
+// Example:
+//
+//	s := NewDefault()
+//	http.ListenAndServe(":8080", AccessLogHandler(mux, s, HTTPLogOptions{
+//		Sink: SlogHTTPSink(logger),
+//	}))
+func AccessLogHandler(next http.Handler, s *Sanitizer, opts HTTPLogOptions) http.Handler {
+	opts = opts.withDefaults()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		rec := &HTTPRecord{
+			Method:        r.Method,
+			URL:           s.sanitizeRequestURL(r.URL),
+			RequestHeader: s.sanitizeHTTPHeader(r.Header, opts.Headers),
+			RequestBody:   HTTPBody{Size: r.ContentLength},
+		}
+		if r.Body != nil && r.Body != http.NoBody && opts.MaxBodySize > 0 {
+			var peeked []byte
+			var truncated bool
+			peeked, truncated, r.Body = peekBody(r.Body, opts.MaxBodySize)
+			rec.RequestBody.Content = s.sanitizeHTTPBody(r.Header.Get("Content-Type"), peeked, truncated)
+			rec.RequestBody.Truncated = truncated
+		}
+
+		rw := &responseRecorder{ResponseWriter: w, body: limitedBuffer{limit: opts.MaxBodySize}}
+		completed := false
+		defer func() {
+			rec.Duration = time.Since(start)
+			rec.Status = rw.status
+			if rec.Status == 0 && completed {
+				// Nothing was written, so net/http responds 200 OK.
+				// A panicking handler leaves the status at 0.
+				rec.Status = http.StatusOK
+			}
+			rec.ResponseHeader = s.sanitizeHTTPHeader(w.Header(), opts.Headers)
+			rec.ResponseBody = HTTPBody{
+				Content:   s.sanitizeHTTPBody(w.Header().Get("Content-Type"), rw.body.buf.Bytes(), rw.body.truncated),
+				Size:      rw.size,
+				Truncated: rw.body.truncated,
+			}
+			opts.Sink(r.Context(), rec)
+		}()
+
+		next.ServeHTTP(rw, r)
+		completed = true
+	})
+}
+
+// withDefaults fills in unset options
+func (o HTTPLogOptions) withDefaults() HTTPLogOptions {
+	if o.Headers == nil {
+		o.Headers = DefaultHTTPLogHeaders
+	}
+	if o.MaxBodySize == 0 {
+		o.MaxBodySize = DefaultMaxBodySize
+	}
+	if o.Sink == nil {
+		o.Sink = func(ctx context.Context, rec *HTTPRecord) {
+			SlogHTTPSink(slog.Default())(ctx, rec)
+		}
+	}
+	return o
+}
+
+// responseRecorder captures the status, size and leading bytes of a response
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int64
+	body   limitedBuffer
+}
+
+// WriteHeader implements http.ResponseWriter
+func (w *responseRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter
+func (w *responseRecorder) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.size += int64(n)
+	w.body.Write(p[:n])
+	return n, err
+}
+
+// Flush implements http.Flusher if the wrapped writer does. Flushing sends the
+// headers, with 200 OK if no status was written.
+func (w *responseRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker for upgrade handlers such as websockets. It returns
+// an error wrapping http.ErrNotSupported if the wrapped writer cannot be hijacked.
+// A hijacked response without a status is recorded as 101 Switching Protocols.
+func (w *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("sanitizer: %T cannot hijack: %w", w.ResponseWriter, http.ErrNotSupported)
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil && w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController
+func (w *responseRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// limitedBuffer keeps the first limit bytes written to it
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+// Write implements io.Writer, never failing
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.limit <= 0 {
+		// Body capture is disabled
+		return len(p), nil
+	}
+	if room := b.limit - b.buf.Len(); room < len(p) {
+		if len(p) > 0 {
+			b.truncated = true
+		}
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	b.buf.Write(p)
+	return len(p), nil
+}
+
+// peekBody reads up to limit bytes of body for logging. The returned body replays
+// them followed by the rest of the stream (or the read error), so the consumer
+// sees exactly the original bytes.
+func peekBody(body io.ReadCloser, limit int) (peeked []byte, truncated bool, replay io.ReadCloser) {
+	data, err := io.ReadAll(io.LimitReader(body, int64(limit)+1))
+
+	rest := io.Reader(body)
+	if err != nil {
+		rest = errReader{err}
+	}
+	replay = readCloser{Reader: io.MultiReader(bytes.NewReader(data), rest), Closer: body}
+
+	if len(data) > limit {
+		return data[:limit], true, replay
+	}
+	return data, false, replay
+}
+
+// readCloser combines a reader with the closer of the original body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// errReader returns err on every read
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// sanitizeRequestURL sanitizes a request URL for logging
+func (s *Sanitizer) sanitizeRequestURL(u *url.URL) string {
+	return s.SanitizeURL(u.String())
+}
+
+// sanitizeHTTPHeader returns the named headers present in h, sanitized with SanitizeHeader
+func (s *Sanitizer) sanitizeHTTPHeader(h http.Header, names []string) http.Header {
+	selected := make(http.Header, len(names))
+	for _, name := range names {
+		name = http.CanonicalHeaderKey(name)
+		if values := h.Values(name); len(values) > 0 {
+			selected[name] = values
+		}
+	}
+	return s.SanitizeHeader(selected)
+}
+
+// sanitizeHTTPBody sanitizes a captured body according to its content type.
+// Truncated JSON and XML keep their complete leading tokens; bodies that are neither
+// structured nor text yield an empty string.
+func (s *Sanitizer) sanitizeHTTPBody(contentType string, body []byte, truncated bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		sanitized, err := s.sanitizeJSONDocument(body, jsonOrderedOptions{})
+		if err == nil || truncated {
+			return string(sanitized)
+		}
+	case mediaType == "application/xml", mediaType == "text/xml", strings.HasSuffix(mediaType, "+xml"):
+		var sanitized bytes.Buffer
+		err := s.SanitizeXML(bytes.NewReader(body), &sanitized)
+		if err == nil || truncated {
+			return sanitized.String()
+		}
+	case mediaType == "application/x-www-form-urlencoded":
+		return s.sanitizeQuery(string(body))
+	case mediaType == "multipart/form-data":
+		return s.sanitizeMultipartForm(body, params["boundary"])
+	case strings.HasPrefix(mediaType, "text/"):
+	case mediaType == "" && utf8.Valid(body):
+	default:
+		return ""
+	}
+	return s.sanitizeText(string(body))
+}
+
+// sanitizeMultipartForm renders a multipart form as a logfmt line: form fields
+// with their sanitized values and files as their name, content type and size.
+// Parts cut off by the capture limit are omitted.
+func (s *Sanitizer) sanitizeMultipartForm(body []byte, boundary string) string {
+	var out []byte
+	mr := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			break
+		}
+
+		var value string
+		if filename := part.FileName(); filename != "" {
+			n, err := io.Copy(io.Discard, part)
+			if err != nil {
+				break
+			}
+			value = "file " + s.sanitizeText(filename) + " (" + strconv.FormatInt(n, 10) + " bytes"
+			if fileType := part.Header.Get("Content-Type"); fileType != "" {
+				value += ", " + fileType
+			}
+			value += ")"
+		} else {
+			data, err := io.ReadAll(part)
+			if err != nil {
+				break
+			}
+			value = s.SanitizeField(part.FormName(), string(data))
+			if s.isRemoved(string(data), value) {
+				continue
+			}
+		}
+
+		if len(out) > 0 {
+			out = append(out, ' ')
+		}
+		out = append(out, part.FormName()...)
+		out = append(out, '=')
+		out = appendLogfmtValue(out, value, false)
+	}
+	return string(out)
+}
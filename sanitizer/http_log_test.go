@@ -0,0 +1,330 @@
+package sanitizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// captureHTTPRecords returns options whose sink appends records to recs
+func captureHTTPRecords(recs *[]*HTTPRecord) HTTPLogOptions {
+	return HTTPLogOptions{
+		Headers: []string{"Content-Type", "Authorization", "Cookie", "X-User-Email"},
+		Sink: func(_ context.Context, rec *HTTPRecord) {
+			*recs = append(*recs, rec)
+		},
+	}
+}
+
+func TestAccessLogHandler(t *testing.T) {
+	s := NewDefault()
+
+	var recs []*HTTPRecord
+	var received string
+	handler := AccessLogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"ORD-1","contact":{"phone":"+6591234567"}}`))
+	}), s, captureHTTPRecords(&recs))
+
+	requestBody := `{"orderId":"ORD-1","email":"user@example.com","items":[{"sku":"A1","note":"call +6591234567"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/users/user@example.com/orders?mobile=%2B6591234567&page=2", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer abc.def.ghi")
+	req.Header.Set("X-User-Email", "user@example.com")
+	req.Header.Set("X-Trace", "user@example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if received != requestBody {
+		t.Errorf("Expected handler to receive the original body, got %s", received)
+	}
+	if rec.Body.String() != `{"id":"ORD-1","contact":{"phone":"+6591234567"}}` {
+		t.Errorf("Expected response to be sent unchanged, got %s", rec.Body.String())
+	}
+	if len(recs) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(recs))
+	}
+
+	r := recs[0]
+	if r.Method != http.MethodPost || r.Status != http.StatusCreated {
+		t.Errorf("Expected POST with 201, got %s %d", r.Method, r.Status)
+	}
+	if expected := "/users/[REDACTED]/orders?mobile=[REDACTED]&page=2"; r.URL != expected {
+		t.Errorf("URL: got %s, want %s", r.URL, expected)
+	}
+	if expected := `{"orderId":"ORD-1","email":"[REDACTED]","items":[{"sku":"A1","note":"[REDACTED]"}]}`; r.RequestBody.Content != expected {
+		t.Errorf("Request body: got %s, want %s", r.RequestBody.Content, expected)
+	}
+	if r.RequestBody.Size != int64(len(requestBody)) || r.RequestBody.Truncated {
+		t.Errorf("Expected full request body of %d bytes, got %+v", len(requestBody), r.RequestBody)
+	}
+	if expected := `{"id":"ORD-1","contact":{"phone":"[REDACTED]"}}`; r.ResponseBody.Content != expected {
+		t.Errorf("Response body: got %s, want %s", r.ResponseBody.Content, expected)
+	}
+
+	expectedHeaders := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {"Bearer [REDACTED]"},
+		"X-User-Email":  {"[REDACTED]"},
+	}
+	for name, values := range expectedHeaders {
+		if got := r.RequestHeader[name]; len(got) != 1 || got[0] != values[0] {
+			t.Errorf("Request header %s: got %v, want %v", name, got, values)
+		}
+	}
+	if _, logged := r.RequestHeader["X-Trace"]; logged {
+		t.Error("Expected unselected headers not to be logged")
+	}
+}
+
+func TestAccessLogHandler_Bodies(t *testing.T) {
+	s := NewDefault()
+
+	var form bytes.Buffer
+	mw := multipart.NewWriter(&form)
+	_ = mw.WriteField("email", "user@example.com")
+	_ = mw.WriteField("orderId", "ORD-1")
+	fw, _ := mw.CreateFormFile("receipt", "user@example.com.pdf")
+	_, _ = fw.Write([]byte("%PDF-1.4"))
+	_ = mw.Close()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    string
+	}{
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "fullName=John+Doe&orderId=ORD-1&note=call+%2B6591234567",
+			expected:    "fullName=[REDACTED]&orderId=ORD-1&note=[REDACTED]",
+		},
+		{
+			name:        "multipart",
+			contentType: mw.FormDataContentType(),
+			body:        form.String(),
+			expected:    `email=[REDACTED] orderId=ORD-1 receipt="file [REDACTED] (8 bytes, application/octet-stream)"`,
+		},
+		{
+			name:        "soap",
+			contentType: "application/soap+xml; charset=utf-8",
+			body:        `<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><Nm>John Tan</Nm><Id>ORD-1</Id></soap:Body></soap:Envelope>`,
+			expected:    `<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><Nm>[REDACTED]</Nm><Id>ORD-1</Id></soap:Body></soap:Envelope>`,
+		},
+		{
+			name:        "text",
+			contentType: "text/plain; charset=utf-8",
+			body:        "please call +6591234567",
+			expected:    "please call [REDACTED]",
+		},
+		{
+			name:        "json array",
+			contentType: "application/vnd.api+json",
+			body:        `[{"email":"user@example.com"},"ORD-1"]`,
+			expected:    `[{"email":"[REDACTED]"},"ORD-1"]`,
+		},
+		{
+			name:        "invalid json",
+			contentType: "application/json",
+			body:        `email=user@example.com`,
+			expected:    `email=[REDACTED]`,
+		},
+		{
+			name:        "binary",
+			contentType: "application/octet-stream",
+			body:        "user@example.com",
+			expected:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recs []*HTTPRecord
+			handler := AccessLogHandler(http.NotFoundHandler(), s, captureHTTPRecords(&recs))
+
+			req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got := recs[0].RequestBody.Content; got != tt.expected {
+				t.Errorf("got  %s\nwant %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAccessLogHandler_Truncation(t *testing.T) {
+	s := NewDefault()
+
+	var recs []*HTTPRecord
+	var received int
+	opts := captureHTTPRecords(&recs)
+	opts.MaxBodySize = 40
+	handler := AccessLogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = len(body)
+		_, _ = io.WriteString(w, strings.Repeat("call +6591234567. ", 5))
+	}), s, opts)
+
+	body := `{"fullName":"John Doe","orderId":"ORD-1","email":"user@example.com"}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	r := recs[0]
+	if received != len(body) {
+		t.Errorf("Expected handler to read all %d bytes, got %d", len(body), received)
+	}
+	if !r.RequestBody.Truncated || r.RequestBody.Content != `{"fullName":"[REDACTED]","orderId":"ORD-1"` {
+		t.Errorf("Expected complete leading JSON tokens, got %+v", r.RequestBody)
+	}
+	if !r.ResponseBody.Truncated || r.ResponseBody.Size != 90 || strings.Contains(r.ResponseBody.Content, "6591234567") {
+		t.Errorf("Expected truncated, sanitized response body, got %+v", r.ResponseBody)
+	}
+}
+
+func TestAccessLogHandler_NoBodies(t *testing.T) {
+	s := NewDefault()
+
+	var recs []*HTTPRecord
+	opts := captureHTTPRecords(&recs)
+	opts.MaxBodySize = -1
+	handler := AccessLogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "user@example.com")
+	}), s, opts)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader("user@example.com")))
+
+	r := recs[0]
+	if r.RequestBody.Content != "" || r.ResponseBody.Content != "" || r.ResponseBody.Truncated {
+		t.Errorf("Expected bodies not to be captured, got %+v / %+v", r.RequestBody, r.ResponseBody)
+	}
+	if r.Status != http.StatusOK || r.ResponseBody.Size != 16 {
+		t.Errorf("Expected status and size to be recorded, got %d / %d", r.Status, r.ResponseBody.Size)
+	}
+}
+
+func TestAccessLogHandler_Hijack(t *testing.T) {
+	s := NewDefault()
+
+	// The sink runs on the server goroutine once the handler returns
+	recs := make(chan *HTTPRecord, 1)
+	opts := HTTPLogOptions{Sink: func(_ context.Context, rec *HTTPRecord) { recs <- rec }}
+	handler := AccessLogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, ok := w.(http.Hijacker)
+		if !ok {
+			t.Error("Expected the wrapped writer to implement http.Hijacker")
+			return
+		}
+		conn, rw, err := h.Hijack()
+		if err != nil {
+			t.Errorf("Hijack() error = %v", err)
+			return
+		}
+		defer conn.Close()
+		_, _ = rw.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 2\r\nConnection: close\r\n\r\nok")
+		_ = rw.Flush()
+	}), s, opts)
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(body) != "ok" {
+		t.Errorf("Expected the hijacked connection to carry the response, got %q", body)
+	}
+	select {
+	case rec := <-recs:
+		if rec.Status != http.StatusSwitchingProtocols {
+			t.Errorf("Expected status 101, got %d", rec.Status)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected a record for the hijacked request")
+	}
+
+	// A writer that cannot be hijacked reports http.ErrNotSupported
+	handler = AccessLogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := w.(http.Hijacker).Hijack(); !errors.Is(err, http.ErrNotSupported) {
+			t.Errorf("Hijack() error = %v, want http.ErrNotSupported", err)
+		}
+	}), s, HTTPLogOptions{Sink: func(context.Context, *HTTPRecord) {}})
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestSlogHTTPSink(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	handler := AccessLogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no account for user@example.com", http.StatusInternalServerError)
+	}), s, HTTPLogOptions{Sink: SlogHTTPSink(logger)})
+
+	req := httptest.NewRequest(http.MethodGet, "/lookup?email=user@example.com", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if strings.Contains(buf.String(), "user@example.com") {
+		t.Fatalf("Expected PII to be redacted, got %s", buf.String())
+	}
+
+	var entry struct {
+		Level string
+		HTTP  struct {
+			Method   string
+			URL      string
+			Status   int
+			Response struct {
+				Headers map[string]string
+				Body    string
+			}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse log output: %v\n%s", err, buf.String())
+	}
+	if entry.Level != "ERROR" || entry.HTTP.Method != "GET" || entry.HTTP.Status != 500 {
+		t.Errorf("Expected error-level GET with 500, got %+v", entry)
+	}
+	if entry.HTTP.URL != "/lookup?email=[REDACTED]" {
+		t.Errorf("Expected sanitized URL, got %s", entry.HTTP.URL)
+	}
+	if entry.HTTP.Response.Headers["Content-Type"] != "text/plain; charset=utf-8" {
+		t.Errorf("Expected default headers to be logged, got %v", entry.HTTP.Response.Headers)
+	}
+	if entry.HTTP.Response.Body != "no account for [REDACTED]\n" {
+		t.Errorf("Expected sanitized response body, got %q", entry.HTTP.Response.Body)
+	}
+}
+
+func TestAccessLogHandler_Panic(t *testing.T) {
+	s := NewDefault()
+
+	var recs []*HTTPRecord
+	handler := RecoverHandler(AccessLogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}), s, captureHTTPRecords(&recs)), s, func(*http.Request, *PanicError) {})
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if len(recs) != 1 || recs[0].Status != 0 {
+		t.Errorf("Expected a record without status for the failed request, got %+v", recs)
+	}
+}
@@ -0,0 +1,133 @@
+package sanitizer
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// accessLogTransport is an http.RoundTripper that logs sanitized requests and responses
+type accessLogTransport struct {
+	next      http.RoundTripper
+	sanitizer *Sanitizer
+	opts      HTTPLogOptions
+}
+
+// AccessLogTransport wraps next (http.DefaultTransport if nil) so that every outbound
+// request and its response are logged with PII redacted, like AccessLogHandler.
+// Logged headers are sanitized with SanitizeHeader, so credentials in Authorization,
+// Proxy-Authorization, Cookie and Set-Cookie are always redacted.
+//
+// The bytes sent and received are not altered: the request body is captured up to
+// opts.MaxBodySize and replayed to next, and the response body is captured as the
+// caller reads it. The record is sent to the sink when the response body reaches
+// EOF or is closed, or immediately if the round trip fails.
+//
+// Example:
+//
+//	s := NewDefault()
+//	client := &http.Client{Transport: AccessLogTransport(nil, s, HTTPLogOptions{
+//		Sink: SlogHTTPSink(logger),
+//	})}
+func AccessLogTransport(next http.RoundTripper, s *Sanitizer, opts HTTPLogOptions) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &accessLogTransport{next: next, sanitizer: s, opts: opts.withDefaults()}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *accessLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s := t.sanitizer
+	start := time.Now()
+
+	rec := &HTTPRecord{
+		Method:        req.Method,
+		URL:           s.sanitizeRequestURL(req.URL),
+		RequestHeader: s.sanitizeHTTPHeader(req.Header, t.opts.Headers),
+		RequestBody:   HTTPBody{Size: req.ContentLength},
+	}
+	if req.Body != nil && req.Body != http.NoBody && t.opts.MaxBodySize > 0 {
+		// RoundTrippers must not modify the caller's request
+		clone := *req
+		peeked, truncated, replay := peekBody(req.Body, t.opts.MaxBodySize)
+		clone.Body = replay
+		req = &clone
+		rec.RequestBody.Content = s.sanitizeHTTPBody(req.Header.Get("Content-Type"), peeked, truncated)
+		rec.RequestBody.Truncated = truncated
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	rec.Duration = time.Since(start)
+	if err != nil {
+		rec.Error = s.Error(err).Error()
+		t.opts.Sink(req.Context(), rec)
+		return resp, err
+	}
+
+	rec.Status = resp.StatusCode
+	rec.ResponseHeader = s.sanitizeHTTPHeader(resp.Header, t.opts.Headers)
+	rec.ResponseBody.Size = resp.ContentLength
+
+	body := &loggedBody{
+		ReadCloser: resp.Body,
+		buf:        limitedBuffer{limit: t.opts.MaxBodySize},
+		emit: func(captured *loggedBody) {
+			rec.ResponseBody.Content = s.sanitizeHTTPBody(resp.Header.Get("Content-Type"), captured.buf.buf.Bytes(), captured.buf.truncated)
+			rec.ResponseBody.Truncated = captured.buf.truncated || (!captured.eof && t.opts.MaxBodySize > 0)
+			if rec.ResponseBody.Size < 0 && captured.eof {
+				rec.ResponseBody.Size = captured.size
+			}
+			t.opts.Sink(req.Context(), rec)
+		},
+	}
+	if resp.Body == nil || resp.Body == http.NoBody {
+		body.finish(true)
+		return resp, nil
+	}
+	resp.Body = body
+	return resp, nil
+}
+
+// loggedBody captures a response body as it is read and emits the record once,
+// at EOF or on Close. Close may be called concurrently with Read to cancel it.
+type loggedBody struct {
+	io.ReadCloser
+	mu   sync.Mutex
+	buf  limitedBuffer
+	size int64
+	eof  bool
+	once sync.Once
+	emit func(*loggedBody)
+}
+
+// Read implements io.Reader
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.mu.Lock()
+	b.size += int64(n)
+	b.buf.Write(p[:n])
+	b.mu.Unlock()
+	if err == io.EOF {
+		b.finish(true)
+	}
+	return n, err
+}
+
+// Close implements io.Closer
+func (b *loggedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.finish(false)
+	return err
+}
+
+// finish emits the record on the first call
+func (b *loggedBody) finish(eof bool) {
+	b.once.Do(func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.eof = eof
+		b.emit(b)
+	})
+}
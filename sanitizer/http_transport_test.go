@@ -0,0 +1,217 @@
+package sanitizer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// wireCapture records what an httptest.Server received
+type wireCapture struct {
+	body   string
+	header http.Header
+}
+
+// newWireServer returns a server that records each request and replies with response
+func newWireServer(t *testing.T, got *wireCapture, response string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got.body, got.header = string(body), r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=s3cr3t; HttpOnly")
+		_, _ = io.WriteString(w, response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAccessLogTransport(t *testing.T) {
+	s := NewDefault()
+
+	var wire wireCapture
+	response := `{"applicant":{"fullName":"John Doe","status":"approved"}}`
+	server := newWireServer(t, &wire, response)
+
+	var recs []*HTTPRecord
+	opts := captureHTTPRecords(&recs)
+	opts.Headers = append(opts.Headers, "Set-Cookie")
+	client := &http.Client{Transport: AccessLogTransport(nil, s, opts)}
+
+	requestBody := `{"email":"user@example.com","status":"pending"}`
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/v1/applicants?phone=%2B6591234567", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer abc.def.ghi")
+	req.Header.Set("Cookie", "session=s3cr3t")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	received, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	// The wire is untouched in both directions
+	if wire.body != requestBody {
+		t.Errorf("Expected server to receive the original body, got %s", wire.body)
+	}
+	if wire.header.Get("Authorization") != "Bearer abc.def.ghi" || wire.header.Get("Cookie") != "session=s3cr3t" {
+		t.Errorf("Expected credentials to be sent unchanged, got %v", wire.header)
+	}
+	if string(received) != response {
+		t.Errorf("Expected client to receive the original response, got %s", received)
+	}
+
+	if len(recs) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(recs))
+	}
+	r := recs[0]
+	if expected := server.URL + "/v1/applicants?phone=[REDACTED]"; r.URL != expected {
+		t.Errorf("URL: got %s, want %s", r.URL, expected)
+	}
+	if r.Status != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", r.Status)
+	}
+	if expected := `{"email":"[REDACTED]","status":"pending"}`; r.RequestBody.Content != expected {
+		t.Errorf("Request body: got %s, want %s", r.RequestBody.Content, expected)
+	}
+	if expected := `{"applicant":{"fullName":"[REDACTED]","status":"approved"}}`; r.ResponseBody.Content != expected {
+		t.Errorf("Response body: got %s, want %s", r.ResponseBody.Content, expected)
+	}
+	if r.ResponseBody.Size != int64(len(response)) || r.ResponseBody.Truncated {
+		t.Errorf("Expected complete response of %d bytes, got %+v", len(response), r.ResponseBody)
+	}
+	expectedHeaders := map[string]string{
+		"Authorization": "Bearer [REDACTED]",
+		"Cookie":        "session=[REDACTED]",
+	}
+	for header, expected := range expectedHeaders {
+		if got := r.RequestHeader.Get(header); got != expected {
+			t.Errorf("Expected %s to be %q, got %q", header, expected, got)
+		}
+	}
+	if got := r.ResponseHeader.Get("Set-Cookie"); got != "session=[REDACTED]; HttpOnly" {
+		t.Errorf("Expected Set-Cookie value to be redacted, got %q", got)
+	}
+}
+
+func TestAccessLogTransport_BodyLimit(t *testing.T) {
+	s := NewDefault()
+
+	var wire wireCapture
+	response := strings.Repeat("sent to user@example.com; ", 10)
+	server := newWireServer(t, &wire, response)
+
+	var recs []*HTTPRecord
+	opts := captureHTTPRecords(&recs)
+	opts.MaxBodySize = 32
+	client := &http.Client{Transport: AccessLogTransport(nil, s, opts)}
+
+	requestBody := strings.Repeat("call +6591234567 ", 10)
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader(requestBody))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	received, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if wire.body != requestBody || string(received) != response {
+		t.Error("Expected bodies beyond the capture limit to be transferred in full")
+	}
+
+	r := recs[0]
+	if !r.RequestBody.Truncated || !strings.HasPrefix(r.RequestBody.Content, "call [REDACTED] call ") ||
+		strings.Contains(r.RequestBody.Content, "659123") {
+		t.Errorf("Expected truncated, sanitized request body, got %+v", r.RequestBody)
+	}
+	if !r.ResponseBody.Truncated || len(r.ResponseBody.Content) > 32 || strings.Contains(r.ResponseBody.Content, "@example.com") {
+		t.Errorf("Expected truncated, sanitized response body, got %+v", r.ResponseBody)
+	}
+}
+
+func TestAccessLogTransport_ClosedEarly(t *testing.T) {
+	s := NewDefault()
+
+	var wire wireCapture
+	server := newWireServer(t, &wire, `{"orderId":"ORD-1","status":"shipped"}`)
+
+	var recs []*HTTPRecord
+	client := &http.Client{Transport: AccessLogTransport(nil, s, captureHTTPRecords(&recs))}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	buf := make([]byte, 10)
+	_, _ = io.ReadFull(resp.Body, buf)
+	if len(recs) != 0 {
+		t.Fatal("Expected record to wait until the body is done")
+	}
+	_ = resp.Body.Close()
+	_ = resp.Body.Close()
+
+	if len(recs) != 1 {
+		t.Fatalf("Expected exactly 1 record after Close, got %d", len(recs))
+	}
+	if !recs[0].ResponseBody.Truncated || recs[0].ResponseBody.Content != `{"orderId"` {
+		t.Errorf("Expected partially read body to be marked truncated, got %+v", recs[0].ResponseBody)
+	}
+}
+
+func TestAccessLogTransport_Error(t *testing.T) {
+	s := NewDefault()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	var recs []*HTTPRecord
+	client := &http.Client{Transport: AccessLogTransport(nil, s, captureHTTPRecords(&recs))}
+
+	if _, err := client.Get(server.URL + "/users/user@example.com"); err == nil {
+		t.Fatal("Expected request to a closed server to fail")
+	}
+	if len(recs) != 1 || recs[0].Status != 0 || recs[0].Error == "" {
+		t.Fatalf("Expected a failed record with an error, got %+v", recs)
+	}
+	if strings.Contains(recs[0].Error+recs[0].URL, "user@example.com") {
+		t.Errorf("Expected URL and error to be sanitized, got %s / %s", recs[0].URL, recs[0].Error)
+	}
+}
+
+func TestAccessLogTransport_RequestNotModified(t *testing.T) {
+	s := NewDefault()
+
+	var wire wireCapture
+	server := newWireServer(t, &wire, "ok")
+
+	var seen *http.Request
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		seen = req
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	transport := AccessLogTransport(next, s, HTTPLogOptions{Sink: func(context.Context, *HTTPRecord) {}})
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL, bytes.NewReader([]byte("payload")))
+	body := req.Body
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if req.Body != body || seen == req {
+		t.Error("Expected the caller's request to be left unmodified")
+	}
+	if wire.body != "payload" {
+		t.Errorf("Expected server to receive the payload, got %q", wire.body)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
@@ -0,0 +1,692 @@
+package sanitizer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ISO8583Encoding is the wire encoding of an ISO 8583 message part
+type ISO8583Encoding int
+
+const (
+	// ISO8583ASCII encodes one character per byte; binary fields are hex digits
+	ISO8583ASCII ISO8583Encoding = iota
+
+	// ISO8583BCD packs two digits per byte. Track 2 separators are the nibble D.
+	ISO8583BCD
+
+	// ISO8583Binary is raw bytes. As a length prefix, LL is one byte and LLL two bytes.
+	ISO8583Binary
+)
+
+// ISO8583FieldKind selects how a data element is sanitized
+type ISO8583FieldKind int
+
+const (
+	// ISO8583Scan fields are checked against content patterns and masked on a match
+	ISO8583Scan ISO8583FieldKind = iota
+
+	// ISO8583Keep fields are never changed (amounts, dates, codes, MACs)
+	ISO8583Keep
+
+	// ISO8583PAN fields are truncated to the first six and last four digits (PCI DSS)
+	ISO8583PAN
+
+	// ISO8583Track fields keep a truncated PAN and separators; expiry, service code,
+	// cardholder name and discretionary data are masked
+	ISO8583Track
+
+	// ISO8583Redact fields are always redacted (PIN blocks, ICC data, additional data)
+	ISO8583Redact
+)
+
+// ISO8583Field describes a data element
+type ISO8583Field struct {
+	Name string
+
+	// Length is the fixed length, or the maximum length of a variable field, in
+	// characters for ASCII, digits for BCD and bytes for binary
+	Length int
+
+	// Prefix is the number of length digits of a variable field: 2 (LLVAR) or
+	// 3 (LLLVAR). Zero means fixed length.
+	Prefix int
+
+	Encoding ISO8583Encoding
+
+	// PadRight pads odd-length BCD values on the right with F (track 2 data)
+	// instead of on the left with 0
+	PadRight bool
+
+	Kind ISO8583FieldKind
+}
+
+// ISO8583Spec describes the layout of ISO 8583 messages
+type ISO8583Spec struct {
+	// HeaderLength is the number of bytes before the MTI (e.g. a TPDU), copied unchanged
+	HeaderLength int
+
+	// MTIEncoding is ISO8583ASCII (4 bytes) or ISO8583BCD (2 bytes)
+	MTIEncoding ISO8583Encoding
+
+	// BitmapEncoding is ISO8583Binary (8 bytes) or ISO8583ASCII (16 hex digits)
+	BitmapEncoding ISO8583Encoding
+
+	// PrefixEncoding encodes the length prefixes of variable fields
+	PrefixEncoding ISO8583Encoding
+
+	// Fields maps data element numbers (2-128) to their layout
+	Fields map[int]ISO8583Field
+}
+
+// iso8583Element is an ISO 8583:1987 data element definition
+type iso8583Element struct {
+	num    int
+	name   string
+	typ    string // n, an, ans, z, b (length in bits) or x+n
+	length int
+	prefix int
+}
+
+// iso8583Elements are the ISO 8583:1987 data elements
+var iso8583Elements = []iso8583Element{
+	{2, "Primary account number", "n", 19, 2},
+	{3, "Processing code", "n", 6, 0},
+	{4, "Amount, transaction", "n", 12, 0},
+	{5, "Amount, settlement", "n", 12, 0},
+	{6, "Amount, cardholder billing", "n", 12, 0},
+	{7, "Transmission date and time", "n", 10, 0},
+	{8, "Amount, cardholder billing fee", "n", 8, 0},
+	{9, "Conversion rate, settlement", "n", 8, 0},
+	{10, "Conversion rate, cardholder billing", "n", 8, 0},
+	{11, "System trace audit number", "n", 6, 0},
+	{12, "Time, local transaction", "n", 6, 0},
+	{13, "Date, local transaction", "n", 4, 0},
+	{14, "Date, expiration", "n", 4, 0},
+	{15, "Date, settlement", "n", 4, 0},
+	{16, "Date, conversion", "n", 4, 0},
+	{17, "Date, capture", "n", 4, 0},
+	{18, "Merchant type", "n", 4, 0},
+	{19, "Acquiring institution country code", "n", 3, 0},
+	{20, "PAN extended, country code", "n", 3, 0},
+	{21, "Forwarding institution country code", "n", 3, 0},
+	{22, "Point of service entry mode", "n", 3, 0},
+	{23, "Card sequence number", "n", 3, 0},
+	{24, "Network international identifier", "n", 3, 0},
+	{25, "Point of service condition code", "n", 2, 0},
+	{26, "Point of service capture code", "n", 2, 0},
+	{27, "Authorizing identification response length", "n", 1, 0},
+	{28, "Amount, transaction fee", "x+n", 8, 0},
+	{29, "Amount, settlement fee", "x+n", 8, 0},
+	{30, "Amount, transaction processing fee", "x+n", 8, 0},
+	{31, "Amount, settlement processing fee", "x+n", 8, 0},
+	{32, "Acquiring institution identification code", "n", 11, 2},
+	{33, "Forwarding institution identification code", "n", 11, 2},
+	{34, "Primary account number, extended", "ans", 28, 2},
+	{35, "Track 2 data", "z", 37, 2},
+	{36, "Track 3 data", "n", 104, 3},
+	{37, "Retrieval reference number", "an", 12, 0},
+	{38, "Authorization identification response", "an", 6, 0},
+	{39, "Response code", "an", 2, 0},
+	{40, "Service restriction code", "an", 3, 0},
+	{41, "Card acceptor terminal identification", "ans", 8, 0},
+	{42, "Card acceptor identification code", "ans", 15, 0},
+	{43, "Card acceptor name/location", "ans", 40, 0},
+	{44, "Additional response data", "an", 25, 2},
+	{45, "Track 1 data", "an", 76, 2},
+	{46, "Additional data, ISO", "an", 999, 3},
+	{47, "Additional data, national", "an", 999, 3},
+	{48, "Additional data, private", "an", 999, 3},
+	{49, "Currency code, transaction", "n", 3, 0},
+	{50, "Currency code, settlement", "n", 3, 0},
+	{51, "Currency code, cardholder billing", "n", 3, 0},
+	{52, "Personal identification number data", "b", 64, 0},
+	{53, "Security related control information", "n", 16, 0},
+	{54, "Additional amounts", "an", 120, 3},
+	{55, "ICC data", "ans", 999, 3},
+	{56, "Reserved ISO", "ans", 999, 3},
+	{57, "Reserved national", "ans", 999, 3},
+	{58, "Reserved national", "ans", 999, 3},
+	{59, "Reserved national", "ans", 999, 3},
+	{60, "Reserved national", "ans", 999, 3},
+	{61, "Reserved private", "ans", 999, 3},
+	{62, "Reserved private", "ans", 999, 3},
+	{63, "Reserved private", "ans", 999, 3},
+	{64, "Message authentication code", "b", 64, 0},
+	{65, "Extended bitmap indicator", "b", 8, 0},
+	{66, "Settlement code", "n", 1, 0},
+	{67, "Extended payment code", "n", 2, 0},
+	{68, "Receiving institution country code", "n", 3, 0},
+	{69, "Settlement institution country code", "n", 3, 0},
+	{70, "Network management information code", "n", 3, 0},
+	{71, "Message number", "n", 4, 0},
+	{72, "Message number, last", "n", 4, 0},
+	{73, "Date, action", "n", 6, 0},
+	{74, "Credits, number", "n", 10, 0},
+	{75, "Credits, reversal number", "n", 10, 0},
+	{76, "Debits, number", "n", 10, 0},
+	{77, "Debits, reversal number", "n", 10, 0},
+	{78, "Transfer, number", "n", 10, 0},
+	{79, "Transfer, reversal number", "n", 10, 0},
+	{80, "Inquiries, number", "n", 10, 0},
+	{81, "Authorizations, number", "n", 10, 0},
+	{82, "Credits, processing fee amount", "n", 12, 0},
+	{83, "Credits, transaction fee amount", "n", 12, 0},
+	{84, "Debits, processing fee amount", "n", 12, 0},
+	{85, "Debits, transaction fee amount", "n", 12, 0},
+	{86, "Credits, amount", "n", 16, 0},
+	{87, "Credits, reversal amount", "n", 16, 0},
+	{88, "Debits, amount", "n", 16, 0},
+	{89, "Debits, reversal amount", "n", 16, 0},
+	{90, "Original data elements", "n", 42, 0},
+	{91, "File update code", "an", 1, 0},
+	{92, "File security code", "an", 2, 0},
+	{93, "Response indicator", "an", 5, 0},
+	{94, "Service indicator", "an", 7, 0},
+	{95, "Replacement amounts", "an", 42, 0},
+	{96, "Message security code", "b", 64, 0},
+	{97, "Amount, net settlement", "x+n", 16, 0},
+	{98, "Payee", "ans", 25, 0},
+	{99, "Settlement institution identification code", "n", 11, 2},
+	{100, "Receiving institution identification code", "n", 11, 2},
+	{101, "File name", "ans", 17, 2},
+	{102, "Account identification 1", "ans", 28, 2},
+	{103, "Account identification 2", "ans", 28, 2},
+	{104, "Transaction description", "ans", 100, 3},
+	{105, "Reserved ISO", "ans", 999, 3},
+	{106, "Reserved ISO", "ans", 999, 3},
+	{107, "Reserved ISO", "ans", 999, 3},
+	{108, "Reserved ISO", "ans", 999, 3},
+	{109, "Reserved ISO", "ans", 999, 3},
+	{110, "Reserved ISO", "ans", 999, 3},
+	{111, "Reserved ISO", "ans", 999, 3},
+	{112, "Reserved national", "ans", 999, 3},
+	{113, "Reserved national", "ans", 999, 3},
+	{114, "Reserved national", "ans", 999, 3},
+	{115, "Reserved national", "ans", 999, 3},
+	{116, "Reserved national", "ans", 999, 3},
+	{117, "Reserved national", "ans", 999, 3},
+	{118, "Reserved national", "ans", 999, 3},
+	{119, "Reserved national", "ans", 999, 3},
+	{120, "Reserved private", "ans", 999, 3},
+	{121, "Reserved private", "ans", 999, 3},
+	{122, "Reserved private", "ans", 999, 3},
+	{123, "Reserved private", "ans", 999, 3},
+	{124, "Reserved private", "ans", 999, 3},
+	{125, "Reserved private", "ans", 999, 3},
+	{126, "Reserved private", "ans", 999, 3},
+	{127, "Reserved private", "ans", 999, 3},
+	{128, "Message authentication code", "b", 64, 0},
+}
+
+// iso8583Kinds are the sanitization kinds of sensitive data elements; other numeric
+// and binary elements are kept and the rest are scanned
+var iso8583Kinds = map[int]ISO8583FieldKind{
+	2:   ISO8583PAN,
+	14:  ISO8583Redact,
+	34:  ISO8583PAN,
+	35:  ISO8583Track,
+	36:  ISO8583Track,
+	45:  ISO8583Track,
+	46:  ISO8583Redact,
+	47:  ISO8583Redact,
+	48:  ISO8583Redact,
+	52:  ISO8583Redact,
+	53:  ISO8583Redact,
+	55:  ISO8583Redact,
+	98:  ISO8583Redact,
+	102: ISO8583Redact,
+	103: ISO8583Redact,
+	104: ISO8583Redact,
+}
+
+// NewISO8583ASCIISpec returns an ISO 8583:1987 spec with every part ASCII encoded:
+// a 4-character MTI, a hex bitmap, ASCII length prefixes and binary fields as hex digits.
+//
+// Sensitive elements are preset: PANs (DE 2, 34) are truncated, track data (DE 35, 36, 45)
+// is masked after the PAN, and the expiry date (DE 14), additional data (DE 46-48),
+// PIN data (DE 52), security control information (DE 53), ICC data (DE 55), payee
+// (DE 98), account identification (DE 102, 103) and transaction description (DE 104)
+// are redacted. Networks that carry cardholder names in private fields (e.g. DE 63)
+// should set those fields to ISO8583Redact.
+func NewISO8583ASCIISpec() *ISO8583Spec {
+	return newISO8583Spec(ISO8583ASCII)
+}
+
+// NewISO8583BCDSpec returns an ISO 8583:1987 spec for the packed variant: a BCD MTI,
+// a binary bitmap, BCD length prefixes, BCD numeric and track 2 fields, raw binary
+// fields and ASCII text fields. Sensitive elements are preset as in NewISO8583ASCIISpec.
+func NewISO8583BCDSpec() *ISO8583Spec {
+	return newISO8583Spec(ISO8583BCD)
+}
+
+// newISO8583Spec builds the ISO 8583:1987 spec for an ASCII or BCD variant
+func newISO8583Spec(variant ISO8583Encoding) *ISO8583Spec {
+	spec := &ISO8583Spec{
+		MTIEncoding:    variant,
+		BitmapEncoding: ISO8583Binary,
+		PrefixEncoding: variant,
+		Fields:         make(map[int]ISO8583Field, len(iso8583Elements)),
+	}
+	if variant == ISO8583ASCII {
+		spec.BitmapEncoding = ISO8583ASCII
+	}
+
+	for _, e := range iso8583Elements {
+		field := ISO8583Field{Name: e.name, Length: e.length, Prefix: e.prefix, Encoding: ISO8583ASCII}
+		switch e.typ {
+		case "n", "z":
+			field.Kind = ISO8583Keep
+			field.Encoding = variant
+			field.PadRight = e.typ == "z"
+		case "b":
+			field.Kind = ISO8583Keep
+			field.Length = e.length / 8
+			field.Encoding = ISO8583Binary
+			if variant == ISO8583ASCII {
+				field.Length = e.length / 4
+				field.Encoding = ISO8583ASCII
+			}
+		case "x+n":
+			field.Length++
+		}
+		if kind, ok := iso8583Kinds[e.num]; ok {
+			field.Kind = kind
+		}
+		spec.Fields[e.num] = field
+	}
+	return spec
+}
+
+// iso8583Value is a parsed data element
+type iso8583Value struct {
+	num   int
+	field ISO8583Field
+
+	// start and end locate the encoded value in the message, after any length prefix
+	start, end int
+
+	// value is the decoded value: text for ASCII, digits for BCD, hex for binary
+	value string
+}
+
+// SanitizeISO8583 returns a copy of an ISO 8583 message with sensitive data elements
+// masked according to spec. Every field keeps its length so that the message still
+// parses: masked characters are written as '*' in ASCII fields, as 0 digits in BCD
+// fields and as zero bytes in binary fields. PANs keep their first six and last four
+// digits.
+//
+// Returns an error if the message does not match spec.
+//
+// Example:
+//
+//	clean, err := s.SanitizeISO8583(msg, sanitizer.NewISO8583BCDSpec())
+func (s *Sanitizer) SanitizeISO8583(msg []byte, spec *ISO8583Spec) ([]byte, error) {
+	_, values, err := parseISO8583(msg, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(msg))
+	copy(out, msg)
+	for _, v := range values {
+		masked := s.maskISO8583Value(v)
+		if masked == v.value {
+			continue
+		}
+		encoded, err := encodeISO8583Value(masked, v.field)
+		if err != nil || len(encoded) != v.end-v.start {
+			return nil, fmt.Errorf("sanitizer: ISO 8583 field %d: cannot encode masked value", v.num)
+		}
+		copy(out[v.start:v.end], encoded)
+	}
+	return out, nil
+}
+
+// DumpISO8583 parses an ISO 8583 message according to spec and returns a sanitized
+// field dump for logs, one line per element:
+//
+//	MTI 0200
+//	DE002 Primary account number: 411111******1111
+//	DE004 Amount, transaction: 000000010000
+//	DE052 Personal identification number data: [REDACTED]
+//
+// PANs and track data are truncated as in SanitizeISO8583; redacted elements use the
+// configured strategy and are omitted under StrategyRemove. Binary values are shown
+// as hex and text with control characters is quoted.
+func (s *Sanitizer) DumpISO8583(msg []byte, spec *ISO8583Spec) (string, error) {
+	mti, values, err := parseISO8583(msg, spec)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("MTI ")
+	b.WriteString(mti)
+	b.WriteByte('\n')
+	for _, v := range values {
+		var value string
+		switch v.field.Kind {
+		case ISO8583Redact:
+			value = s.redact(v.value)
+		case ISO8583Scan:
+			value = s.sanitizeContent(v.value)
+		default:
+			value = s.maskISO8583Value(v)
+		}
+		if s.isRemoved(v.value, value) {
+			continue
+		}
+
+		fmt.Fprintf(&b, "DE%03d", v.num)
+		if v.field.Name != "" {
+			b.WriteByte(' ')
+			b.WriteString(v.field.Name)
+		}
+		b.WriteString(": ")
+		if isPrintableASCII(value) {
+			b.WriteString(value)
+		} else {
+			b.WriteString(strconv.Quote(value))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+// maskISO8583Value returns the sanitized value of a data element, with the same
+// length as the decoded value
+func (s *Sanitizer) maskISO8583Value(v iso8583Value) string {
+	switch v.field.Kind {
+	case ISO8583Keep:
+		return v.value
+	case ISO8583PAN:
+		return truncatePAN(v.value)
+	case ISO8583Track:
+		return maskTrack(v.value)
+	case ISO8583Redact:
+		return strings.Repeat("*", len(v.value))
+	}
+	if v.value != "" && s.sanitizeContent(v.value) != v.value {
+		return strings.Repeat("*", len(v.value))
+	}
+	return v.value
+}
+
+// truncatePAN keeps the first six and last four digits of a PAN, as PCI DSS allows.
+// Shorter numbers keep only the last four digits, or nothing below eight.
+func truncatePAN(pan string) string {
+	first, last := 6, 4
+	switch {
+	case len(pan) < 8:
+		first, last = 0, 0
+	case len(pan) < first+last+3:
+		first = 0
+	}
+	return pan[:first] + strings.Repeat("*", len(pan)-first-last) + pan[len(pan)-last:]
+}
+
+// maskTrack truncates the PAN in track data and masks everything after it except
+// the field separators. A leading format code (B in track 1) or sentinel is kept.
+func maskTrack(track string) string {
+	start := strings.IndexAny(track, "0123456789")
+	if start < 0 {
+		return strings.Repeat("*", len(track))
+	}
+	end := start
+	for end < len(track) && track[end] >= '0' && track[end] <= '9' {
+		end++
+	}
+
+	masked := []byte(track[:start] + truncatePAN(track[start:end]) + track[end:])
+	for i := end; i < len(masked); i++ {
+		if masked[i] != '=' && masked[i] != '^' && masked[i] != '?' {
+			masked[i] = '*'
+		}
+	}
+	return string(masked)
+}
+
+// parseISO8583 splits a message into its MTI and data elements in field order
+func parseISO8583(msg []byte, spec *ISO8583Spec) (string, []iso8583Value, error) {
+	pos := spec.HeaderLength
+	if pos > len(msg) {
+		return "", nil, errISO8583Truncated("header")
+	}
+
+	mtiLength := 4
+	if spec.MTIEncoding == ISO8583BCD {
+		mtiLength = 2
+	}
+	if pos+mtiLength > len(msg) {
+		return "", nil, errISO8583Truncated("MTI")
+	}
+	mti := string(msg[pos : pos+mtiLength])
+	if spec.MTIEncoding == ISO8583BCD {
+		mti = decodeBCD(msg[pos:pos+mtiLength], 4, false)
+	}
+	pos += mtiLength
+
+	bitmap, n, err := readISO8583Bitmap(msg[pos:], spec.BitmapEncoding)
+	if err != nil {
+		return "", nil, err
+	}
+	pos += n
+	if bitmap[0]&0x80 != 0 {
+		secondary, n, err := readISO8583Bitmap(msg[pos:], spec.BitmapEncoding)
+		if err != nil {
+			return "", nil, err
+		}
+		bitmap = append(bitmap, secondary...)
+		pos += n
+	}
+
+	var values []iso8583Value
+	for num := 2; num <= len(bitmap)*8; num++ {
+		if bitmap[(num-1)/8]&(0x80>>((num-1)%8)) == 0 {
+			continue
+		}
+		field, ok := spec.Fields[num]
+		if !ok {
+			return "", nil, fmt.Errorf("sanitizer: ISO 8583 field %d is not in the spec", num)
+		}
+
+		length := field.Length
+		if field.Prefix > 0 {
+			var n int
+			length, n, err = readISO8583Length(msg[pos:], field.Prefix, spec.PrefixEncoding)
+			if err != nil {
+				return "", nil, fmt.Errorf("sanitizer: ISO 8583 field %d: %w", num, err)
+			}
+			if length > field.Length {
+				return "", nil, fmt.Errorf("sanitizer: ISO 8583 field %d: length %d exceeds %d", num, length, field.Length)
+			}
+			pos += n
+		}
+
+		size := length
+		if field.Encoding == ISO8583BCD {
+			size = (length + 1) / 2
+		}
+		if pos+size > len(msg) {
+			return "", nil, errISO8583Truncated(fmt.Sprintf("field %d", num))
+		}
+
+		v := iso8583Value{num: num, field: field, start: pos, end: pos + size}
+		switch field.Encoding {
+		case ISO8583BCD:
+			v.value = decodeBCD(msg[pos:pos+size], length, field.PadRight)
+		case ISO8583Binary:
+			v.value = strings.ToUpper(hex.EncodeToString(msg[pos : pos+size]))
+		default:
+			v.value = string(msg[pos : pos+size])
+		}
+		values = append(values, v)
+		pos += size
+	}
+
+	if pos != len(msg) {
+		return "", nil, fmt.Errorf("sanitizer: ISO 8583 message has %d unexpected trailing bytes", len(msg)-pos)
+	}
+	return mti, values, nil
+}
+
+// readISO8583Bitmap reads an 8-byte bitmap, returning it and the bytes consumed
+func readISO8583Bitmap(data []byte, encoding ISO8583Encoding) ([]byte, int, error) {
+	if encoding != ISO8583ASCII {
+		if len(data) < 8 {
+			return nil, 0, errISO8583Truncated("bitmap")
+		}
+		return append([]byte(nil), data[:8]...), 8, nil
+	}
+	if len(data) < 16 {
+		return nil, 0, errISO8583Truncated("bitmap")
+	}
+	bitmap, err := hex.DecodeString(string(data[:16]))
+	if err != nil {
+		return nil, 0, fmt.Errorf("sanitizer: ISO 8583 bitmap: %w", err)
+	}
+	return bitmap, 16, nil
+}
+
+// readISO8583Length reads a variable field's length prefix of the given number of
+// digits, returning the length and the bytes consumed
+func readISO8583Length(data []byte, digits int, encoding ISO8583Encoding) (int, int, error) {
+	size := digits
+	if encoding != ISO8583ASCII {
+		size = (digits + 1) / 2
+	}
+	if len(data) < size {
+		return 0, 0, fmt.Errorf("truncated length prefix")
+	}
+
+	switch encoding {
+	case ISO8583BCD:
+		length, ok := parseISO8583Digits(decodeBCD(data[:size], digits, false))
+		if !ok {
+			return 0, 0, fmt.Errorf("invalid BCD length prefix %X", data[:size])
+		}
+		return length, size, nil
+	case ISO8583Binary:
+		length := 0
+		for _, b := range data[:size] {
+			length = length<<8 | int(b)
+		}
+		return length, size, nil
+	}
+	length, ok := parseISO8583Digits(string(data[:size]))
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid length prefix %q", data[:size])
+	}
+	return length, size, nil
+}
+
+// parseISO8583Digits parses a length prefix made only of decimal digits, rejecting
+// the signs that strconv.Atoi would accept
+func parseISO8583Digits(digits string) (int, bool) {
+	length := 0
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return 0, false
+		}
+		length = length*10 + int(digits[i]-'0')
+	}
+	return length, digits != ""
+}
+
+// decodeBCD unpacks digits from packed BCD. An odd number of digits has a pad nibble
+// on the left, or on the right if padRight is set. The nibble D decodes as '=' (the
+// track 2 separator) and other non-decimal nibbles as hex digits.
+func decodeBCD(data []byte, digits int, padRight bool) string {
+	nibbles := make([]byte, 0, len(data)*2)
+	for _, b := range data {
+		nibbles = append(nibbles, bcdDigit(b>>4), bcdDigit(b&0x0f))
+	}
+	if len(nibbles) > digits {
+		if padRight {
+			nibbles = nibbles[:digits]
+		} else {
+			nibbles = nibbles[len(nibbles)-digits:]
+		}
+	}
+	return string(nibbles)
+}
+
+// bcdDigit returns the character for a BCD nibble
+func bcdDigit(nibble byte) byte {
+	switch {
+	case nibble <= 9:
+		return '0' + nibble
+	case nibble == 0xd:
+		return '='
+	}
+	return "0123456789ABCDEF"[nibble]
+}
+
+// encodeISO8583Value encodes a decoded value in the field's encoding. Masked
+// characters ('*') become 0 digits in BCD and zero nibbles in binary fields.
+func encodeISO8583Value(value string, field ISO8583Field) ([]byte, error) {
+	switch field.Encoding {
+	case ISO8583BCD:
+		nibbles := []byte(value)
+		if len(nibbles)%2 != 0 {
+			if field.PadRight {
+				nibbles = append(nibbles, 'F')
+			} else {
+				nibbles = append([]byte{'0'}, nibbles...)
+			}
+		}
+		return packNibbles(nibbles)
+	case ISO8583Binary:
+		return packNibbles([]byte(value))
+	}
+	return []byte(value), nil
+}
+
+// packNibbles packs pairs of digit, hex or '=' characters into bytes
+func packNibbles(chars []byte) ([]byte, error) {
+	if len(chars)%2 != 0 {
+		return nil, fmt.Errorf("odd number of nibbles")
+	}
+	out := make([]byte, len(chars)/2)
+	for i, c := range chars {
+		var nibble byte
+		switch {
+		case c == '*':
+			nibble = 0
+		case c == '=':
+			nibble = 0xd
+		case c >= '0' && c <= '9':
+			nibble = c - '0'
+		case c >= 'A' && c <= 'F':
+			nibble = c - 'A' + 10
+		case c >= 'a' && c <= 'f':
+			nibble = c - 'a' + 10
+		default:
+			return nil, fmt.Errorf("invalid nibble %q", c)
+		}
+		if i%2 == 0 {
+			out[i/2] = nibble << 4
+		} else {
+			out[i/2] |= nibble
+		}
+	}
+	return out, nil
+}
+
+// isPrintableASCII reports whether s contains only printable ASCII characters
+func isPrintableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// errISO8583Truncated reports a message that ends inside the named part
+func errISO8583Truncated(part string) error {
+	return fmt.Errorf("sanitizer: ISO 8583 message truncated in %s", part)
+}
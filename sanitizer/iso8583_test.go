@@ -0,0 +1,289 @@
+package sanitizer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// iso8583Bitmap returns the primary, and if needed secondary, bitmap for fields
+func iso8583Bitmap(fields ...int) []byte {
+	bitmap := make([]byte, 8)
+	for _, num := range fields {
+		if num > 64 && len(bitmap) == 8 {
+			bitmap = append(bitmap, make([]byte, 8)...)
+			bitmap[0] |= 0x80
+		}
+	}
+	for _, num := range fields {
+		bitmap[(num-1)/8] |= 0x80 >> ((num - 1) % 8)
+	}
+	return bitmap
+}
+
+// asciiISO8583 assembles an ASCII-encoded message from pre-encoded fields
+func asciiISO8583(mti string, fields map[int]string) []byte {
+	nums := make([]int, 0, len(fields))
+	for num := 2; num <= 128; num++ {
+		if _, ok := fields[num]; ok {
+			nums = append(nums, num)
+		}
+	}
+	msg := mti + strings.ToUpper(hex.EncodeToString(iso8583Bitmap(nums...)))
+	for _, num := range nums {
+		msg += fields[num]
+	}
+	return []byte(msg)
+}
+
+func TestSanitizeISO8583_ASCII(t *testing.T) {
+	s := NewDefault()
+	spec := NewISO8583ASCIISpec()
+
+	track2 := "4111111111111111=25121011234567890"
+	additional := "CH NAME JOHN TAN"
+	msg := asciiISO8583("0200", map[int]string{
+		2:   "164111111111111111",
+		3:   "000000",
+		4:   "000000010000",
+		14:  "2512",
+		35:  fmt.Sprintf("%02d%s", len(track2), track2),
+		41:  "TERM0001",
+		43:  fmt.Sprintf("%-40s", "ACME STORE SINGAPORE SG"),
+		48:  fmt.Sprintf("%03d%s", len(additional), additional),
+		52:  "1A2B3C4D5E6F7081",
+		62:  "013john@acme.com",
+		102: "101234567890",
+	})
+
+	expected := asciiISO8583("0200", map[int]string{
+		2:   "16411111******1111",
+		3:   "000000",
+		4:   "000000010000",
+		14:  "****",
+		35:  "34411111******1111=*****************",
+		41:  "TERM0001",
+		43:  fmt.Sprintf("%-40s", "ACME STORE SINGAPORE SG"),
+		48:  "016" + strings.Repeat("*", len(additional)),
+		52:  strings.Repeat("*", 16),
+		62:  "013" + strings.Repeat("*", 13),
+		102: "10**********",
+	})
+
+	got, err := s.SanitizeISO8583(msg, spec)
+	if err != nil {
+		t.Fatalf("SanitizeISO8583() error = %v", err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("got:\n%s\nwant:\n%s", got, expected)
+	}
+	if !strings.Contains(string(msg), "4111111111111111") {
+		t.Error("Expected the input message to be left unchanged")
+	}
+
+	dump, err := s.DumpISO8583(msg, spec)
+	if err != nil {
+		t.Fatalf("DumpISO8583() error = %v", err)
+	}
+	expectedDump := "MTI 0200\n" +
+		"DE002 Primary account number: 411111******1111\n" +
+		"DE003 Processing code: 000000\n" +
+		"DE004 Amount, transaction: 000000010000\n" +
+		"DE014 Date, expiration: [REDACTED]\n" +
+		"DE035 Track 2 data: 411111******1111=*****************\n" +
+		"DE041 Card acceptor terminal identification: TERM0001\n" +
+		"DE043 Card acceptor name/location: ACME STORE SINGAPORE SG                 \n" +
+		"DE048 Additional data, private: [REDACTED]\n" +
+		"DE052 Personal identification number data: [REDACTED]\n" +
+		"DE062 Reserved private: [REDACTED]\n" +
+		"DE102 Account identification 1: [REDACTED]\n"
+	if dump != expectedDump {
+		t.Errorf("dump:\n%s\nwant:\n%s", dump, expectedDump)
+	}
+}
+
+func TestSanitizeISO8583_BCD(t *testing.T) {
+	s := NewDefault()
+	spec := NewISO8583BCDSpec()
+	spec.HeaderLength = 5
+
+	header := []byte{0x60, 0x00, 0x03, 0x00, 0x00}
+	build := func(pan, track2, pin []byte, icc string) []byte {
+		msg := append([]byte{}, header...)
+		msg = append(msg, 0x02, 0x00)
+		msg = append(msg, iso8583Bitmap(2, 3, 35, 52, 55)...)
+		msg = append(msg, 0x15)
+		msg = append(msg, pan...)
+		msg = append(msg, 0x00, 0x00, 0x00)
+		msg = append(msg, 0x25)
+		msg = append(msg, track2...)
+		msg = append(msg, pin...)
+		msg = append(msg, 0x00, 0x04)
+		return append(msg, icc...)
+	}
+
+	// Amex PAN 378282246310005 (odd length, left padded) and track 2
+	// 4111111111111111=25121011 (odd length, right padded with F)
+	msg := build(
+		[]byte{0x03, 0x78, 0x28, 0x22, 0x46, 0x31, 0x00, 0x05},
+		[]byte{0x41, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0xD2, 0x51, 0x21, 0x01, 0x1F},
+		[]byte{0x1A, 0x2B, 0x3C, 0x4D, 0x5E, 0x6F, 0x70, 0x81},
+		"\x9f\x26\x01\x02",
+	)
+	expected := build(
+		[]byte{0x03, 0x78, 0x28, 0x20, 0x00, 0x00, 0x00, 0x05},
+		[]byte{0x41, 0x11, 0x11, 0x00, 0x00, 0x00, 0x11, 0x11, 0xD0, 0x00, 0x00, 0x00, 0x0F},
+		make([]byte, 8),
+		"****",
+	)
+
+	got, err := s.SanitizeISO8583(msg, spec)
+	if err != nil {
+		t.Fatalf("SanitizeISO8583() error = %v", err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("got  %X\nwant %X", got, expected)
+	}
+
+	dump, err := s.DumpISO8583(msg, spec)
+	if err != nil {
+		t.Fatalf("DumpISO8583() error = %v", err)
+	}
+	expectedDump := "MTI 0200\n" +
+		"DE002 Primary account number: 378282*****0005\n" +
+		"DE003 Processing code: 000000\n" +
+		"DE035 Track 2 data: 411111******1111=********\n" +
+		"DE052 Personal identification number data: [REDACTED]\n" +
+		"DE055 ICC data: [REDACTED]\n"
+	if dump != expectedDump {
+		t.Errorf("dump:\n%s\nwant:\n%s", dump, expectedDump)
+	}
+}
+
+func TestDumpISO8583_Strategy(t *testing.T) {
+	spec := NewISO8583ASCIISpec()
+	msg := asciiISO8583("0100", map[int]string{
+		2:  "164111111111111111",
+		14: "2512",
+		39: "00",
+		43: fmt.Sprintf("%-40s", "call +6591234567"),
+	})
+
+	removed, err := New(NewDefaultConfig().WithStrategy(StrategyRemove)).DumpISO8583(msg, spec)
+	if err != nil {
+		t.Fatalf("DumpISO8583() error = %v", err)
+	}
+	if expected := "MTI 0100\nDE002 Primary account number: 411111******1111\nDE039 Response code: 00\n"; removed != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", removed, expected)
+	}
+
+	// Binary output masks by length regardless of strategy
+	sanitized, err := New(NewDefaultConfig().WithStrategy(StrategyRemove)).SanitizeISO8583(msg, spec)
+	if err != nil {
+		t.Fatalf("SanitizeISO8583() error = %v", err)
+	}
+	if !bytes.HasSuffix(sanitized, []byte("****00"+strings.Repeat("*", 40))) {
+		t.Errorf("Expected length-preserving masks, got %s", sanitized)
+	}
+}
+
+func TestSanitizeISO8583_CustomSpec(t *testing.T) {
+	s := NewDefault()
+
+	spec := NewISO8583ASCIISpec()
+	field := spec.Fields[63]
+	field.Name = "Cardholder name"
+	field.Kind = ISO8583Redact
+	spec.Fields[63] = field
+	spec.PrefixEncoding = ISO8583Binary
+
+	msg := asciiISO8583("0100", map[int]string{
+		3:  "000000",
+		63: "\x00\x08JOHN TAN",
+	})
+	dump, err := s.DumpISO8583(msg, spec)
+	if err != nil {
+		t.Fatalf("DumpISO8583() error = %v", err)
+	}
+	if expected := "MTI 0100\nDE003 Processing code: 000000\nDE063 Cardholder name: [REDACTED]\n"; dump != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", dump, expected)
+	}
+}
+
+func TestSanitizeISO8583_Errors(t *testing.T) {
+	s := NewDefault()
+
+	withoutField := NewISO8583ASCIISpec()
+	delete(withoutField.Fields, 3)
+
+	tests := []struct {
+		name string
+		msg  []byte
+		spec *ISO8583Spec
+		err  string
+	}{
+		{"short MTI", []byte("02"), NewISO8583ASCIISpec(), "truncated in MTI"},
+		{"short bitmap", []byte("02007000"), NewISO8583ASCIISpec(), "truncated in bitmap"},
+		{"bad bitmap", []byte("0200ZZ00000000000000"), NewISO8583ASCIISpec(), "bitmap"},
+		{"truncated field", asciiISO8583("0200", map[int]string{2: "164111"}), NewISO8583ASCIISpec(), "truncated in field 2"},
+		{"length too long", asciiISO8583("0200", map[int]string{2: "20" + strings.Repeat("4", 20)}), NewISO8583ASCIISpec(), "length 20 exceeds 19"},
+		{"bad length", asciiISO8583("0200", map[int]string{2: "1X"}), NewISO8583ASCIISpec(), "invalid length prefix"},
+		{"negative length", []byte("0200" + "4000000000000000" + "-1"), NewISO8583ASCIISpec(), "invalid length prefix"},
+		{"signed length", []byte("0200" + "4000000000000000" + "+1" + "4"), NewISO8583ASCIISpec(), "invalid length prefix"},
+		{"unknown field", asciiISO8583("0200", map[int]string{3: "000000"}), withoutField, "field 3 is not in the spec"},
+		{"trailing bytes", append(asciiISO8583("0200", map[int]string{3: "000000"}), "xx"...), NewISO8583ASCIISpec(), "2 unexpected trailing bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.SanitizeISO8583(tt.msg, tt.spec)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected error containing %q, got %v", tt.err, err)
+			}
+			if _, dumpErr := s.DumpISO8583(tt.msg, tt.spec); dumpErr == nil {
+				t.Error("Expected DumpISO8583 to fail too")
+			}
+		})
+	}
+}
+
+func TestTruncatePAN(t *testing.T) {
+	tests := []struct {
+		pan      string
+		expected string
+	}{
+		{"4111111111111111", "411111******1111"},
+		{"6011000990139424123", "601100*********4123"},
+		{"378282246310005", "378282*****0005"},
+		{"123456789012", "********9012"},
+		{"1234567890", "******7890"},
+		{"1234567", "*******"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := truncatePAN(tt.pan); got != tt.expected {
+			t.Errorf("truncatePAN(%q) = %q, expected %q", tt.pan, got, tt.expected)
+		}
+	}
+}
+
+func TestMaskTrack(t *testing.T) {
+	tests := []struct {
+		track    string
+		expected string
+	}{
+		{"4111111111111111=25121011234567890", "411111******1111=*****************"},
+		{";4111111111111111=2512101?", ";411111******1111=*******?"},
+		{"B4111111111111111^TAN/JOHN^2512101000", "B411111******1111^********^**********"},
+		{"NOPAN", "*****"},
+	}
+
+	for _, tt := range tests {
+		if got := maskTrack(tt.track); got != tt.expected {
+			t.Errorf("maskTrack(%q) = %q, expected %q", tt.track, got, tt.expected)
+		}
+	}
+}
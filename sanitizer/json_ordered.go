@@ -0,0 +1,360 @@
+package sanitizer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// errNotJSONObject is returned when ordered JSON sanitization is given something other than an object
+var errNotJSONObject = errors.New("sanitizer: input is not a JSON object")
+
+// jsonOrderedOptions controls top-level behaviour of sanitizeJSONOrdered
+type jsonOrderedOptions struct {
+	// preserve lists top-level keys whose values are copied unchanged (e.g. level, time)
+	preserve map[string]bool
+
+	// dedupe drops repeated occurrences of these top-level keys, keeping the first
+	dedupe map[string]bool
+
+	// text lists top-level keys holding free text, where only PII spans are redacted
+	text map[string]bool
+}
+
+// sanitizeJSONOrdered sanitizes a JSON object like SanitizeJSON, but streams tokens
+// so that key order, number formatting and HTML characters are preserved exactly.
+func (s *Sanitizer) sanitizeJSONOrdered(data []byte, opts jsonOrderedOptions) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, errNotJSONObject
+	}
+
+	w := &jsonOrderedWriter{sanitizer: s, dec: dec}
+	if err := w.object(0, "", opts); err != nil {
+		return nil, err
+	}
+
+	// Reject trailing data such as a second object on the same line
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errNotJSONObject
+	}
+
+	return w.buf.Bytes(), nil
+}
+
+// sanitizeJSONDocument sanitizes a JSON object or array like sanitizeJSONOrdered.
+// On a syntax error it returns the sanitized output written so far along with the
+// error, so a truncated document still yields its complete leading tokens.
+func (s *Sanitizer) sanitizeJSONDocument(data []byte, opts jsonOrderedOptions) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	w := &jsonOrderedWriter{sanitizer: s, dec: dec}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		err = w.object(0, "", opts)
+	case json.Delim('['):
+		err = w.array(0, "")
+	default:
+		return nil, errNotJSONObject
+	}
+	if err != nil {
+		return w.buf.Bytes(), err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return w.buf.Bytes(), errNotJSONObject
+	}
+	return w.buf.Bytes(), nil
+}
+
+// jsonOrderedWriter re-encodes a JSON token stream while sanitizing string values
+type jsonOrderedWriter struct {
+	sanitizer *Sanitizer
+	dec       *json.Decoder
+	buf       bytes.Buffer
+}
+
+// object writes the members of an object whose opening brace has been consumed.
+// path is the object's schema path, used only if a Schema is configured; schema
+// rules take precedence over opts, as in SanitizeMap.
+func (w *jsonOrderedWriter) object(depth int, path string, opts jsonOrderedOptions) error {
+	w.buf.WriteByte('{')
+	seen := make(map[string]bool)
+	first := true
+
+	for w.dec.More() {
+		keyTok, err := w.dec.Token()
+		if err != nil {
+			return err
+		}
+		key := keyTok.(string)
+
+		tok, err := w.dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if opts.dedupe[key] && seen[key] {
+			if err := w.skip(tok); err != nil {
+				return err
+			}
+			continue
+		}
+		seen[key] = true
+
+		var childPath string
+		var rule schemaRule
+		if schema := w.sanitizer.config.Schema; schema != nil {
+			childPath, rule = schema.child(path, key)
+			if w.sanitizer.schemaRedacts(rule) {
+				redacted, keep := w.sanitizer.applySchemaRule(tok)
+				if err := w.skip(tok); err != nil {
+					return err
+				}
+				if !keep {
+					continue
+				}
+				if !first {
+					w.buf.WriteByte(',')
+				}
+				first = false
+				w.writeString(key)
+				w.buf.WriteByte(':')
+				w.writeRedacted(redacted)
+				continue
+			}
+		}
+
+		// Strings are sanitized before the key is written so removed fields leave no trace
+		var str string
+		isString := false
+		if v, ok := tok.(string); ok {
+			isString = true
+			str = v
+			switch {
+			case rule.action != schemaNone:
+				// Preserved by the schema
+			case opts.text[key]:
+				str = w.sanitizer.sanitizeText(v)
+			case !opts.preserve[key]:
+				str = w.sanitizer.SanitizeField(key, v)
+				if w.sanitizer.isRemoved(v, str) {
+					continue
+				}
+			}
+		}
+
+		if !first {
+			w.buf.WriteByte(',')
+		}
+		first = false
+		w.writeString(key)
+		w.buf.WriteByte(':')
+
+		switch {
+		case isString:
+			w.writeString(str)
+		case opts.preserve[key] && rule.action == schemaNone:
+			if err := w.copyValue(tok); err != nil {
+				return err
+			}
+		default:
+			if err := w.value(tok, depth+1, childPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Consume the closing brace
+	if _, err := w.dec.Token(); err != nil {
+		return err
+	}
+	w.buf.WriteByte('}')
+	return nil
+}
+
+// array writes the elements of an array whose opening bracket has been consumed.
+// Array elements have no field name, so strings are checked by content only. path
+// is the array's schema path, used only if a Schema is configured.
+func (w *jsonOrderedWriter) array(depth int, path string) error {
+	w.buf.WriteByte('[')
+	first := true
+
+	var itemPath string
+	var rule schemaRule
+	if schema := w.sanitizer.config.Schema; schema != nil {
+		itemPath, rule = schema.items(path)
+	}
+
+	for w.dec.More() {
+		tok, err := w.dec.Token()
+		if err != nil {
+			return err
+		}
+		if !first {
+			w.buf.WriteByte(',')
+		}
+		first = false
+
+		// Removed items keep their position as an empty value
+		if w.sanitizer.schemaRedacts(rule) {
+			redacted, _ := w.sanitizer.applySchemaRule(tok)
+			if err := w.skip(tok); err != nil {
+				return err
+			}
+			w.writeRedacted(redacted)
+			continue
+		}
+
+		if str, ok := tok.(string); ok {
+			if rule.action == schemaNone {
+				str = w.sanitizer.sanitizeContent(str)
+			}
+			w.writeString(str)
+			continue
+		}
+		if err := w.value(tok, depth+1, itemPath); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.dec.Token(); err != nil {
+		return err
+	}
+	w.buf.WriteByte(']')
+	return nil
+}
+
+// value writes a non-string value, recursing into objects and arrays. path is the
+// value's schema path.
+func (w *jsonOrderedWriter) value(tok json.Token, depth int, path string) error {
+	if depth > w.sanitizer.config.MaxDepth {
+		return w.copyValue(tok)
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			return w.object(depth, path, jsonOrderedOptions{})
+		}
+		return w.array(depth, path)
+	case string:
+		w.writeString(w.sanitizer.sanitizeContent(t))
+	default:
+		w.writeScalar(t)
+	}
+	return nil
+}
+
+// copyValue writes a value unchanged
+func (w *jsonOrderedWriter) copyValue(tok json.Token) error {
+	switch t := tok.(type) {
+	case json.Delim:
+		open, close := byte('{'), byte('}')
+		if t == '[' {
+			open, close = '[', ']'
+		}
+		w.buf.WriteByte(open)
+		first := true
+		for w.dec.More() {
+			if !first {
+				w.buf.WriteByte(',')
+			}
+			first = false
+			next, err := w.dec.Token()
+			if err != nil {
+				return err
+			}
+			if t == '{' {
+				w.writeString(next.(string))
+				w.buf.WriteByte(':')
+				if next, err = w.dec.Token(); err != nil {
+					return err
+				}
+			}
+			if err := w.copyValue(next); err != nil {
+				return err
+			}
+		}
+		if _, err := w.dec.Token(); err != nil {
+			return err
+		}
+		w.buf.WriteByte(close)
+	case string:
+		w.writeString(t)
+	default:
+		w.writeScalar(t)
+	}
+	return nil
+}
+
+// skip consumes a value without writing it
+func (w *jsonOrderedWriter) skip(tok json.Token) error {
+	if _, ok := tok.(json.Delim); !ok {
+		return nil
+	}
+	for nesting := 1; nesting > 0; {
+		next, err := w.dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := next.(json.Delim); ok {
+			if d == '{' || d == '[' {
+				nesting++
+			} else {
+				nesting--
+			}
+		}
+	}
+	return nil
+}
+
+// writeRedacted writes a value returned by applySchemaRule
+func (w *jsonOrderedWriter) writeRedacted(v any) {
+	if str, ok := v.(string); ok {
+		w.writeString(str)
+		return
+	}
+	w.buf.WriteString("null")
+}
+
+// writeScalar writes a number, bool or null token
+func (w *jsonOrderedWriter) writeScalar(tok json.Token) {
+	switch t := tok.(type) {
+	case json.Number:
+		w.buf.WriteString(t.String())
+	case bool:
+		if t {
+			w.buf.WriteString("true")
+		} else {
+			w.buf.WriteString("false")
+		}
+	case nil:
+		w.buf.WriteString("null")
+	}
+}
+
+// writeString writes a JSON string without escaping HTML characters
+func (w *jsonOrderedWriter) writeString(str string) {
+	w.buf.Write(appendJSONString(nil, str))
+}
+
+// appendJSONString appends str as a JSON string literal, leaving <, > and & unescaped
+func appendJSONString(dst []byte, str string) []byte {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(str) // encoding a string cannot fail
+	return append(dst, bytes.TrimSuffix(b.Bytes(), []byte("\n"))...)
+}
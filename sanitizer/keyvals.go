@@ -0,0 +1,74 @@
+package sanitizer
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// sanitizeKeyvals sanitizes alternating key/value pairs as used by logr and go-kit.
+// Each key drives field name matching for its value. Pairs whose value is removed
+// (StrategyRemove) are dropped; a trailing key without a value is kept as-is.
+// Values for which preserve returns true are passed through unchanged (preserve may be nil).
+// The input slice is never modified.
+func (s *Sanitizer) sanitizeKeyvals(keyvals []any, preserve func(value any) bool) []any {
+	if len(keyvals) == 0 {
+		return keyvals
+	}
+
+	result := make([]any, 0, len(keyvals))
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 >= len(keyvals) {
+			result = append(result, keyvals[i])
+			break
+		}
+
+		key := keyvals[i]
+		if preserve != nil && preserve(keyvals[i+1]) {
+			result = append(result, key, keyvals[i+1])
+			continue
+		}
+
+		name, ok := key.(string)
+		if !ok {
+			name = fmt.Sprint(key)
+		}
+
+		if value, keep := s.sanitizeKeyvalValue(name, keyvals[i+1]); keep {
+			result = append(result, key, value)
+		}
+	}
+	return result
+}
+
+// sanitizeKeyvalValue sanitizes a single value from a key/value list.
+// Scalars keep their original type so downstream encoders format them as usual;
+// structs, maps and slices are walked and returned as plain maps and slices.
+// Returns false if the value should be omitted (StrategyRemove).
+func (s *Sanitizer) sanitizeKeyvalValue(key string, v any) (any, bool) {
+	switch val := v.(type) {
+	case nil:
+		return nil, true
+	case string:
+		return s.walkString(key, val, false)
+	case error:
+		// Keep an error value so sinks can still format and unwrap it
+		return s.sanitizeError(key, val)
+	case time.Time, time.Duration:
+		return val, true
+	}
+
+	// Log sinks render Stringers by their String method, so sanitize that text
+	if str, ok := v.(fmt.Stringer); ok {
+		return s.walkString(key, safeStringer(str), false)
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Ptr:
+		value, keep := s.walkStructured(key, v)
+		return structuredToPlain(value), keep
+	}
+
+	// Numbers, bools and functions (e.g. go-kit Valuers) carry no string content
+	return v, true
+}
@@ -0,0 +1,211 @@
+package sanitizer
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// textFieldNames are keys whose values are free text (messages and errors), so only
+// the PII spans inside them are redacted instead of the whole value
+var textFieldNames = map[string]bool{
+	"msg":     true,
+	"message": true,
+	"err":     true,
+	"error":   true,
+}
+
+// logfmtPair is a key=value token (or a bare key) in a logfmt line
+type logfmtPair struct {
+	key        string
+	value      string // unquoted value
+	quoted     bool   // value was written in double quotes
+	bare       bool   // key without '=' or value
+	start      int    // offset of the key
+	valueStart int    // offset of the raw value (after '=')
+	end        int    // offset just past the raw value
+}
+
+// SanitizeLogfmt sanitizes logfmt data (one record per line), such as
+// level=info user_email=a@b.com msg="sent receipt"
+//
+// Keys are matched against field name rules and values against content rules;
+// msg, message, err and error values only have the PII inside them redacted.
+// Token order, spacing, quoting and line endings are preserved, and values are
+// only re-encoded when they change. Bare keys (a key without '=') are kept, unless
+// the key itself is PII. Lines that are not valid logfmt are sanitized as free text,
+// still applying field name rules to the key=value tokens inside them.
+func (s *Sanitizer) SanitizeLogfmt(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		line := data
+		var ending []byte
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, ending = data[:i], data[i:i+1]
+			if i > 0 && data[i-1] == '\r' {
+				line, ending = data[:i-1], data[i-1:i+1]
+			}
+		}
+		data = data[len(line)+len(ending):]
+
+		if sanitized, ok := s.sanitizeLogfmt(line, true); ok {
+			out = append(out, sanitized...)
+		} else if len(bytes.TrimSpace(line)) == 0 {
+			out = append(out, line...)
+		} else {
+			out = append(out, s.sanitizeTextFields(string(line))...)
+		}
+		out = append(out, ending...)
+	}
+	return out
+}
+
+// parseLogfmt splits a line into key=value pairs separated by spaces.
+// Returns false unless the whole line consists of such pairs. Bare keys are
+// only accepted if bareKeys is set, so that free text that merely contains an
+// '=' is not mistaken for logfmt when detecting the format.
+func parseLogfmt(line []byte, bareKeys bool) ([]logfmtPair, bool) {
+	var pairs []logfmtPair
+	i := 0
+	for {
+		for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+			i++
+		}
+		if i == len(line) {
+			break
+		}
+
+		// Key: printable bytes up to '='
+		start := i
+		for i < len(line) && line[i] > ' ' && line[i] != '=' && line[i] != '"' {
+			i++
+		}
+		if i == start {
+			return nil, false
+		}
+		if i == len(line) || line[i] == ' ' || line[i] == '\t' {
+			if !bareKeys {
+				return nil, false
+			}
+			pairs = append(pairs, logfmtPair{key: string(line[start:i]), bare: true, start: start, valueStart: i, end: i})
+			continue
+		}
+		if line[i] != '=' {
+			return nil, false
+		}
+		pair := logfmtPair{key: string(line[start:i]), start: start}
+		i++
+		pair.valueStart = i
+
+		if i < len(line) && line[i] == '"' {
+			// Quoted value with backslash escapes
+			j := i + 1
+			for j < len(line) && line[j] != '"' {
+				if line[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(line) {
+				return nil, false
+			}
+			value, err := strconv.Unquote(string(line[i : j+1]))
+			if err != nil {
+				return nil, false
+			}
+			pair.value = value
+			pair.quoted = true
+			i = j + 1
+		} else {
+			for i < len(line) && line[i] > ' ' && line[i] != '"' {
+				i++
+			}
+			pair.value = string(line[pair.valueStart:i])
+		}
+
+		if i < len(line) && line[i] != ' ' && line[i] != '\t' {
+			return nil, false
+		}
+		pair.end = i
+		pairs = append(pairs, pair)
+	}
+	return pairs, len(pairs) > 0
+}
+
+// sanitizeLogfmt sanitizes a logfmt line, keeping token order, spacing and quoting.
+// Values are only re-encoded when sanitization changes them; removed pairs are
+// dropped together with their separator. Returns false if line is not logfmt.
+func (s *Sanitizer) sanitizeLogfmt(line []byte, bareKeys bool) ([]byte, bool) {
+	pairs, ok := parseLogfmt(line, bareKeys)
+	if !ok {
+		return nil, false
+	}
+
+	out := make([]byte, 0, len(line))
+	last := 0
+	for _, pair := range pairs {
+		original := pair.value
+		var sanitized string
+		switch {
+		case pair.bare:
+			// A bare key has no value, but may itself be PII
+			// (e.g. free text that happens to parse as logfmt)
+			original = pair.key
+			sanitized = s.sanitizeText(pair.key)
+		case textFieldNames[strings.ToLower(pair.key)]:
+			sanitized = s.sanitizeText(pair.value)
+		default:
+			sanitized = s.SanitizeField(pair.key, pair.value)
+		}
+		if sanitized == original {
+			continue
+		}
+
+		if s.isRemoved(original, sanitized) {
+			cut := pair.start
+			for cut > last && (line[cut-1] == ' ' || line[cut-1] == '\t') {
+				cut--
+			}
+			out = append(out, line[last:cut]...)
+			last = pair.end
+			if cut == 0 {
+				// First pair removed: drop the separator after it instead
+				for last < len(line) && (line[last] == ' ' || line[last] == '\t') {
+					last++
+				}
+			}
+			continue
+		}
+
+		if pair.bare {
+			out = append(append(out, line[last:pair.start]...), sanitized...)
+		} else {
+			out = append(out, line[last:pair.valueStart]...)
+			out = appendLogfmtValue(out, sanitized, pair.quoted)
+		}
+		last = pair.end
+	}
+	return append(out, line[last:]...), true
+}
+
+// appendLogfmtValue appends a value, quoting it if it was quoted or needs quoting
+func appendLogfmtValue(dst []byte, value string, quoted bool) []byte {
+	if quoted || logfmtNeedsQuotes(value) {
+		return strconv.AppendQuote(dst, value)
+	}
+	return append(dst, value...)
+}
+
+// logfmtNeedsQuotes reports whether a bare value would not parse back unchanged
+func logfmtNeedsQuotes(value string) bool {
+	if !utf8.ValidString(value) {
+		return true
+	}
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError || !strconv.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package sanitizer
+
+import "testing"
+
+func TestSanitizeLogfmt(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "field names and content",
+			input:    `level=info user_email=a@b.com note=+6591234567 orderId=ORD-1`,
+			expected: `level=info user_email=[REDACTED] note=[REDACTED] orderId=ORD-1`,
+		},
+		{
+			name:     "message keeps surrounding text",
+			input:    `level=warn msg="receipt for a@b.com failed" attempt=2`,
+			expected: `level=warn msg="receipt for [REDACTED] failed" attempt=2`,
+		},
+		{
+			name:     "escapes",
+			input:    `msg="he said \"hi\" to a@b.com\n" tier=gold`,
+			expected: `msg="he said \"hi\" to [REDACTED]\n" tier=gold`,
+		},
+		{
+			name:     "quoting preserved",
+			input:    `phone="+6591234567" fullName="John Doe" city=Tokyo`,
+			expected: `phone="[REDACTED]" fullName="[REDACTED]" city=[REDACTED]`,
+		},
+		{
+			name:     "bare keys",
+			input:    `debug email=a@b.com cached`,
+			expected: `debug email=[REDACTED] cached`,
+		},
+		{
+			name:     "empty value",
+			input:    `email= orderId=ORD-1`,
+			expected: `email= orderId=ORD-1`,
+		},
+		{
+			name:     "spacing preserved",
+			input:    "a=1   email=a@b.com\tb=2 ",
+			expected: "a=1   email=[REDACTED]\tb=2 ",
+		},
+		{
+			name:     "multiple lines",
+			input:    "email=a@b.com\r\n\nid=12345678\nphone=+6591234567",
+			expected: "email=[REDACTED]\r\n\nid=12345678\nphone=[REDACTED]",
+		},
+		{
+			name:     "bare key is pii",
+			input:    "login failed for a@b.com",
+			expected: "login failed for [REDACTED]",
+		},
+		{
+			name:     "malformed line",
+			input:    `msg="unterminated a@b.com`,
+			expected: `msg="unterminated [REDACTED]`,
+		},
+		{
+			name:     "malformed line with pii key",
+			input:    `password=hunter2 msg="unterminated`,
+			expected: `password=[REDACTED] msg="unterminated`,
+		},
+		{
+			name:     "no pii",
+			input:    "ts=1705315800.123 caller=main.go:42 id=12345678\n",
+			expected: "ts=1705315800.123 caller=main.go:42 id=12345678\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(s.SanitizeLogfmt([]byte(tt.input))); got != tt.expected {
+				t.Errorf("got  %q\nwant %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSanitizeLogfmt_RemoveStrategy(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyRemove))
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`email=a@b.com orderId=ORD-1`, `orderId=ORD-1`},
+		{`orderId=ORD-1 email=a@b.com level=info`, `orderId=ORD-1 level=info`},
+		{`debug a@b.com cached`, `debug cached`},
+		{`msg="sent to a@b.com"`, `msg="sent to "`},
+	}
+
+	for _, tt := range tests {
+		if got := string(s.SanitizeLogfmt([]byte(tt.input))); got != tt.expected {
+			t.Errorf("SanitizeLogfmt(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseLogfmt_BareKeys(t *testing.T) {
+	pairs, ok := parseLogfmt([]byte(`debug a=1 cached b="x y"`), true)
+	if !ok || len(pairs) != 4 {
+		t.Fatalf("Expected 4 tokens, got %v (ok=%v)", pairs, ok)
+	}
+
+	expected := []struct {
+		key   string
+		value string
+		bare  bool
+	}{
+		{"debug", "", true},
+		{"a", "1", false},
+		{"cached", "", true},
+		{"b", "x y", false},
+	}
+	for i, want := range expected {
+		if pairs[i].key != want.key || pairs[i].value != want.value || pairs[i].bare != want.bare {
+			t.Errorf("pairs[%d] = %+v, want %+v", i, pairs[i], want)
+		}
+	}
+
+	if _, ok := parseLogfmt([]byte(`debug a=1`), false); ok {
+		t.Error("Expected bare keys to be rejected when not allowed")
+	}
+}
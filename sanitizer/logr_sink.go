@@ -0,0 +1,120 @@
+package sanitizer
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+)
+
+// logrSink sanitizes key/value pairs before passing them to the wrapped logr.LogSink
+type logrSink struct {
+	sink      logr.LogSink
+	sanitizer *Sanitizer
+}
+
+// WrapLogrSink returns a logr.LogSink that sanitizes every key/value pair on
+// Info, Error and WithValues before delegating to sink. Each key drives field
+// name matching for its value, consistent with the slog, zap and zerolog adapters.
+// Errors passed to Error are wrapped with Sanitizer.Error.
+//
+// Example:
+//
+//	s := NewDefault()
+//	logger := logr.New(WrapLogrSink(logger.GetSink(), s))
+//	logger.Info("login", "email", "user@example.com") // "email"="[REDACTED]"
+func WrapLogrSink(sink logr.LogSink, s *Sanitizer) logr.LogSink {
+	// The wrapper adds a stack frame between the caller and the wrapped sink
+	if withDepth, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = withDepth.WithCallDepth(1)
+	}
+	return &logrSink{sink: sink, sanitizer: s}
+}
+
+// WrapLogr returns a copy of logger whose sink sanitizes every key/value pair.
+// See WrapLogrSink.
+func WrapLogr(logger logr.Logger, s *Sanitizer) logr.Logger {
+	if logger.GetSink() == nil {
+		return logger
+	}
+	return logger.WithSink(WrapLogrSink(logger.GetSink(), s))
+}
+
+// Init implements logr.LogSink
+func (l *logrSink) Init(info logr.RuntimeInfo) {
+	l.sink.Init(info)
+}
+
+// Enabled implements logr.LogSink
+func (l *logrSink) Enabled(level int) bool {
+	return l.sink.Enabled(level)
+}
+
+// Info implements logr.LogSink
+func (l *logrSink) Info(level int, msg string, keysAndValues ...any) {
+	l.sink.Info(level, msg, l.sanitizeKeysAndValues(keysAndValues)...)
+}
+
+// Error implements logr.LogSink
+func (l *logrSink) Error(err error, msg string, keysAndValues ...any) {
+	err = l.sanitizer.Error(err)
+	l.sink.Error(err, msg, l.sanitizeKeysAndValues(keysAndValues)...)
+}
+
+// WithValues implements logr.LogSink
+func (l *logrSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &logrSink{
+		sink:      l.sink.WithValues(l.sanitizeKeysAndValues(keysAndValues)...),
+		sanitizer: l.sanitizer,
+	}
+}
+
+// WithName implements logr.LogSink
+func (l *logrSink) WithName(name string) logr.LogSink {
+	return &logrSink{sink: l.sink.WithName(name), sanitizer: l.sanitizer}
+}
+
+// WithCallDepth implements logr.CallDepthLogSink
+func (l *logrSink) WithCallDepth(depth int) logr.LogSink {
+	withDepth, ok := l.sink.(logr.CallDepthLogSink)
+	if !ok {
+		return l
+	}
+	return &logrSink{sink: withDepth.WithCallDepth(depth), sanitizer: l.sanitizer}
+}
+
+// GetCallStackHelper implements logr.CallStackHelperLogSink
+func (l *logrSink) GetCallStackHelper() func() {
+	if helper, ok := l.sink.(logr.CallStackHelperLogSink); ok {
+		return helper.GetCallStackHelper()
+	}
+	return func() {}
+}
+
+// sanitizeKeysAndValues resolves logr.Marshaler values and sanitizes the pairs
+func (l *logrSink) sanitizeKeysAndValues(keysAndValues []any) []any {
+	resolved := keysAndValues
+	copied := false
+	for i := 1; i < len(keysAndValues); i += 2 {
+		marshaler, ok := keysAndValues[i].(logr.Marshaler)
+		if !ok {
+			continue
+		}
+		if !copied {
+			// Callers may reuse their slice, so copy before the first replacement
+			resolved = append([]any(nil), keysAndValues...)
+			copied = true
+		}
+		resolved[i] = safeMarshalLog(marshaler)
+	}
+	return l.sanitizer.sanitizeKeyvals(resolved, nil)
+}
+
+// safeMarshalLog calls MarshalLog, recovering from panics the way logr sinks do
+func safeMarshalLog(m logr.Marshaler) (result any) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = fmt.Sprintf("<PANIC=%v>", r)
+		}
+	}()
+	return m.MarshalLog()
+}
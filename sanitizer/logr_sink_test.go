@@ -0,0 +1,272 @@
+package sanitizer
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+)
+
+// newTestLogr returns a sanitizing funcr JSON logger and a pointer to its last output line
+func newTestLogr(s *Sanitizer) (logr.Logger, *string) {
+	var output string
+	logger := funcr.NewJSON(func(obj string) { output = obj }, funcr.Options{})
+	return WrapLogr(logger, s), &output
+}
+
+// decodeLogr parses a funcr JSON line
+func decodeLogr(t *testing.T, output string) map[string]any {
+	t.Helper()
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(output), &entry); err != nil {
+		t.Fatalf("Failed to parse log output: %v\n%s", err, output)
+	}
+	return entry
+}
+
+func TestLogrIntegration(t *testing.T) {
+	s := NewDefault()
+	logger, output := newTestLogr(s)
+
+	logger.Info("user action",
+		"email", "user@example.com",
+		"fullName", "John Doe",
+		"orderId", "ORD-123",
+		"amount", 100.50,
+	)
+
+	entry := decodeLogr(t, *output)
+	if entry["email"] != "[REDACTED]" {
+		t.Errorf("Expected email to be redacted, got %v", entry["email"])
+	}
+	if entry["fullName"] != "[REDACTED]" {
+		t.Errorf("Expected name to be redacted, got %v", entry["fullName"])
+	}
+	if entry["orderId"] != "ORD-123" {
+		t.Errorf("Expected orderId to be preserved, got %v", entry["orderId"])
+	}
+	if entry["amount"] != 100.50 {
+		t.Errorf("Expected amount to be preserved, got %v", entry["amount"])
+	}
+	if entry["msg"] != "user action" {
+		t.Errorf("Expected message to be untouched, got %v", entry["msg"])
+	}
+}
+
+func TestLogrNested(t *testing.T) {
+	s := NewDefault()
+	logger, output := newTestLogr(s)
+
+	logger.Info("order", "order", map[string]any{
+		"orderId": "ORD-123",
+		"customer": map[string]any{
+			"email": "user@example.com",
+			"phone": "+6591234567",
+		},
+	})
+
+	order := decodeLogr(t, *output)["order"].(map[string]any)
+	customer := order["customer"].(map[string]any)
+	if customer["email"] != "[REDACTED]" || customer["phone"] != "[REDACTED]" {
+		t.Errorf("Expected nested PII to be redacted, got %v", customer)
+	}
+	if order["orderId"] != "ORD-123" {
+		t.Errorf("Expected orderId to be preserved, got %v", order["orderId"])
+	}
+}
+
+func TestLogrSlice(t *testing.T) {
+	s := NewDefault()
+	logger, output := newTestLogr(s)
+
+	logger.Info("contacts", "contacts", []string{"user@example.com", "not-an-email"}, "counts", []int{1, 2})
+
+	entry := decodeLogr(t, *output)
+	contacts := entry["contacts"].([]any)
+	if contacts[0] != "[REDACTED]" || contacts[1] != "not-an-email" {
+		t.Errorf("Expected slice to be sanitized by content, got %v", contacts)
+	}
+	counts := entry["counts"].([]any)
+	if counts[0] != float64(1) || counts[1] != float64(2) {
+		t.Errorf("Expected numbers to be preserved, got %v", counts)
+	}
+}
+
+func TestLogrStruct(t *testing.T) {
+	s := NewDefault()
+	logger, output := newTestLogr(s)
+
+	type User struct {
+		Email   string `json:"email"`
+		OrderID string `json:"orderId"`
+	}
+
+	logger.Info("user", "user", &User{Email: "user@example.com", OrderID: "ORD-123"})
+
+	user := decodeLogr(t, *output)["user"].(map[string]any)
+	if user["email"] != "[REDACTED]" {
+		t.Errorf("Expected email to be redacted, got %v", user["email"])
+	}
+	if user["orderId"] != "ORD-123" {
+		t.Errorf("Expected orderId to be preserved, got %v", user["orderId"])
+	}
+}
+
+func TestLogrMixedTypes(t *testing.T) {
+	s := NewDefault()
+	logger, output := newTestLogr(s)
+
+	logger.Info("test",
+		"string", "user@example.com",
+		"int", 12345,
+		"float", 99.99,
+		"bool", true,
+		"null", nil,
+		"contact", emailStringer{email: "user@example.com"},
+		"cause", errors.New("no account for user@example.com"),
+	)
+
+	entry := decodeLogr(t, *output)
+	if entry["string"] != "[REDACTED]" {
+		t.Errorf("Expected email string to be redacted, got %v", entry["string"])
+	}
+	if entry["int"] != float64(12345) || entry["float"] != 99.99 || entry["bool"] != true {
+		t.Errorf("Expected scalars to be preserved, got %v", entry)
+	}
+	if value, exists := entry["null"]; !exists || value != nil {
+		t.Errorf("Expected null to be preserved, got %v", value)
+	}
+	if entry["contact"] != "[REDACTED]" {
+		t.Errorf("Expected Stringer to be redacted, got %v", entry["contact"])
+	}
+	if entry["cause"] != "no account for [REDACTED]" {
+		t.Errorf("Expected email in error value to be redacted, got %v", entry["cause"])
+	}
+}
+
+func TestLogrError(t *testing.T) {
+	s := NewDefault()
+	logger, output := newTestLogr(s)
+
+	logger.Error(errors.New("lookup failed for user@example.com"), "lookup", "orderId", "ORD-1")
+
+	entry := decodeLogr(t, *output)
+	if strings.Contains(*output, "user@example.com") {
+		t.Errorf("Expected error to be sanitized, got %s", *output)
+	}
+	if entry["error"] != "lookup failed for [REDACTED]" {
+		t.Errorf("Expected email in error to be redacted, got %v", entry["error"])
+	}
+	if entry["orderId"] != "ORD-1" {
+		t.Errorf("Expected orderId to be preserved, got %v", entry["orderId"])
+	}
+}
+
+func TestLogrWithValues(t *testing.T) {
+	s := NewDefault()
+	logger, output := newTestLogr(s)
+
+	keyvals := []any{"email", "user@example.com"}
+	logger.WithValues(keyvals...).WithName("controller").Info("reconcile", "phone", "+6591234567")
+
+	entry := decodeLogr(t, *output)
+	if entry["email"] != "[REDACTED]" || entry["phone"] != "[REDACTED]" {
+		t.Errorf("Expected WithValues and call values to be redacted, got %v", entry)
+	}
+	if entry["logger"] != "controller" {
+		t.Errorf("Expected logger name to be kept, got %v", entry["logger"])
+	}
+	if keyvals[1] != "user@example.com" {
+		t.Error("Expected caller's slice to be left unchanged")
+	}
+}
+
+// secretUser implements logr.Marshaler to control how it is logged
+type secretUser struct{ email string }
+
+func (u secretUser) MarshalLog() any {
+	return map[string]any{"email": u.email}
+}
+
+func TestLogrMarshaler(t *testing.T) {
+	s := NewDefault()
+	logger, output := newTestLogr(s)
+
+	logger.Info("user", "user", secretUser{email: "user@example.com"})
+
+	user := decodeLogr(t, *output)["user"].(map[string]any)
+	if user["email"] != "[REDACTED]" {
+		t.Errorf("Expected MarshalLog result to be sanitized, got %v", user)
+	}
+}
+
+func TestLogrRemoveStrategy(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyRemove))
+	logger, output := newTestLogr(s)
+
+	logger.Info("test", "email", "user@example.com", "orderId", "ORD-1")
+
+	entry := decodeLogr(t, *output)
+	if _, exists := entry["email"]; exists {
+		t.Errorf("Expected email pair to be removed, got %v", entry)
+	}
+	if entry["orderId"] != "ORD-1" {
+		t.Errorf("Expected orderId to be preserved, got %v", entry["orderId"])
+	}
+}
+
+func TestLogrRegionalPatterns(t *testing.T) {
+	s := NewForRegion(Singapore, Malaysia, UAE)
+	logger, output := newTestLogr(s)
+
+	logger.Info("regional",
+		"nric", "S1234567D",
+		"mykad", "901230-14-5678",
+		"iban", "AE07 0331 2345 6789 0123 456",
+	)
+
+	for _, value := range []string{"S1234567D", "901230-14-5678", "AE07 0331 2345 6789 0123 456"} {
+		if strings.Contains(*output, value) {
+			t.Errorf("Expected %s to be redacted, got %s", value, *output)
+		}
+	}
+}
+
+func TestLogrPartialMasking(t *testing.T) {
+	config := NewDefaultConfig().
+		WithStrategy(StrategyPartial).
+		WithPartialMasking('*', 0, 4)
+	s := New(config)
+	logger, output := newTestLogr(s)
+
+	logger.Info("partial", "creditCard", "4532015112830366")
+
+	if entry := decodeLogr(t, *output); entry["creditCard"] != "************0366" {
+		t.Errorf("Expected partial masking, got %v", entry["creditCard"])
+	}
+}
+
+func TestLogrCallerDepth(t *testing.T) {
+	s := NewDefault()
+
+	var output string
+	logger := WrapLogr(funcr.NewJSON(func(obj string) { output = obj }, funcr.Options{LogCaller: funcr.All}), s)
+
+	logger.Info("caller")
+
+	caller := decodeLogr(t, output)["caller"].(map[string]any)
+	if !strings.HasSuffix(caller["file"].(string), "logr_sink_test.go") {
+		t.Errorf("Expected caller to be the test, got %v", caller)
+	}
+}
+
+func TestWrapLogr_Discard(t *testing.T) {
+	s := NewDefault()
+
+	// Must not panic for loggers without a sink
+	WrapLogr(logr.Logger{}, s).Info("test", "email", "user@example.com")
+	WrapLogr(logr.Discard(), s).Info("test", "email", "user@example.com")
+}
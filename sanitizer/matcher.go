@@ -2,6 +2,7 @@ package sanitizer
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -82,18 +83,8 @@ func newContentMatcher(patterns []ContentPattern) *contentMatcher {
 // matches checks if content matches any PII pattern
 func (m *contentMatcher) matches(content string) bool {
 	for _, pattern := range m.patterns {
-		matches := pattern.Pattern.FindAllString(content, -1)
-		if len(matches) > 0 {
-			// If validator exists, use it on each match
-			if pattern.Validator != nil {
-				for _, match := range matches {
-					if pattern.Validator(match) {
-						return true
-					}
-				}
-			} else {
-				return true
-			}
+		if len(pattern.findValues(content, 1)) > 0 {
+			return true
 		}
 	}
 	return false
@@ -102,19 +93,64 @@ func (m *contentMatcher) matches(content string) bool {
 // matchType returns the PII type if content matches, empty string otherwise
 func (m *contentMatcher) matchType(content string) string {
 	for _, pattern := range m.patterns {
-		matches := pattern.Pattern.FindAllString(content, -1)
-		if len(matches) > 0 {
-			// If validator exists, use it on each match
-			if pattern.Validator != nil {
-				for _, match := range matches {
-					if pattern.Validator(match) {
-						return pattern.Name
-					}
-				}
-			} else {
-				return pattern.Name
-			}
+		if len(pattern.findValues(content, 1)) > 0 {
+			return pattern.Name
 		}
 	}
 	return ""
 }
+
+// findValues returns the byte ranges of up to n (all if n < 0) validated PII values
+// matched by the pattern in content. If the pattern has a group named "value", the
+// group is the value and the rest of the match is context, such as a delimiter.
+func (p ContentPattern) findValues(content string, n int) [][2]int {
+	var values [][2]int
+	group := p.Pattern.SubexpIndex("value")
+	for _, loc := range p.Pattern.FindAllStringSubmatchIndex(content, -1) {
+		start, end := loc[0], loc[1]
+		if group > 0 && loc[2*group] >= 0 {
+			start, end = loc[2*group], loc[2*group+1]
+		}
+		if p.Validator != nil && !p.Validator(content[start:end]) {
+			continue
+		}
+		values = append(values, [2]int{start, end})
+		if len(values) == n {
+			break
+		}
+	}
+	return values
+}
+
+// findSpans returns the byte ranges of all validated PII matches in content,
+// sorted by start offset with overlapping matches merged
+func (m *contentMatcher) findSpans(content string) [][2]int {
+	var spans [][2]int
+	for _, pattern := range m.patterns {
+		for _, loc := range pattern.findValues(content, -1) {
+			// Some patterns allow optional trailing separators; keep them out of the span
+			start, end := loc[0], loc[1]
+			for end > start && strings.ContainsRune(" \t-", rune(content[end-1])) {
+				end--
+			}
+			spans = append(spans, [2]int{start, end})
+		}
+	}
+	if len(spans) < 2 {
+		return spans
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+	merged := spans[:1]
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span[0] < last[1] {
+			if span[1] > last[1] {
+				last[1] = span[1]
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
@@ -169,6 +169,35 @@ func TestMatchType_WithValidator(t *testing.T) {
 	}
 }
 
+func TestContentMatcher_ValueGroup(t *testing.T) {
+	var validated []string
+	grouped := ContentPattern{
+		Name:    "ticket",
+		Pattern: regexp.MustCompile(`\b(TKT|REF)-\d{4}\b`),
+		Validator: func(s string) bool {
+			validated = append(validated, s)
+			return true
+		},
+	}
+	named := ContentPattern{
+		Name:    "member",
+		Pattern: regexp.MustCompile(`member:(?P<value>\d{6})`),
+	}
+
+	config := NewDefaultConfig()
+	config.CustomContentPatterns = []ContentPattern{grouped, named}
+	s := New(config)
+
+	// Ordinary groups do not narrow the value; a group named "value" does
+	got := s.sanitizeText("see TKT-1234 for member:123456")
+	if want := "see [REDACTED] for member:[REDACTED]"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if len(validated) != 1 || validated[0] != "TKT-1234" {
+		t.Errorf("Expected validator to see the whole match, got %v", validated)
+	}
+}
+
 func TestFieldMatcher_EdgeCases(t *testing.T) {
 	s := NewDefault()
 
@@ -0,0 +1,406 @@
+package sanitizer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// MIMEAttachmentHeader is added to attachments whose content SanitizeMIME removed,
+// with the decoded size: "removed; size=20480"
+const MIMEAttachmentHeader = "X-Sanitizer-Attachment"
+
+// errMalformedMIMEHeader is returned for a header line without a colon
+var errMalformedMIMEHeader = errors.New("sanitizer: malformed MIME header line")
+
+// mimeAddressHeaders hold address lists; display names and addresses are redacted
+var mimeAddressHeaders = map[string]bool{
+	"From":                        true,
+	"To":                          true,
+	"Cc":                          true,
+	"Bcc":                         true,
+	"Reply-To":                    true,
+	"Sender":                      true,
+	"Return-Path":                 true,
+	"Delivered-To":                true,
+	"X-Original-To":               true,
+	"Resent-From":                 true,
+	"Resent-To":                   true,
+	"Resent-Cc":                   true,
+	"Resent-Sender":               true,
+	"Disposition-Notification-To": true,
+}
+
+// mimeTextHeaders hold free text, possibly RFC 2047 encoded; PII spans are redacted
+var mimeTextHeaders = map[string]bool{
+	"Subject":      true,
+	"Comments":     true,
+	"Keywords":     true,
+	"Thread-Topic": true,
+	"Received":     true,
+}
+
+// mimeKeptHeaders carry identifiers and structure and are never changed
+var mimeKeptHeaders = map[string]bool{
+	"Date":                      true,
+	"Mime-Version":              true,
+	"Message-Id":                true,
+	"In-Reply-To":               true,
+	"References":                true,
+	"Content-Id":                true,
+	"Content-Transfer-Encoding": true,
+	"Content-Language":          true,
+}
+
+// SanitizeMIME streams an email message (RFC 5322 with MIME parts) from r to w with
+// PII redacted:
+//   - From, To, Cc, Reply-To and other address headers have display names and
+//     addresses redacted
+//   - Subject and other text headers, and text and HTML bodies, have PII spans
+//     redacted in place; RFC 2047 encoded words and quoted-printable and base64
+//     bodies are decoded first and re-encoded with the same transfer encoding
+//   - attachments and other non-text parts keep their headers, with file names
+//     sanitized, but their content is removed and flagged with MIMEAttachmentHeader
+//   - forwarded messages (message/rfc822) are sanitized recursively
+//
+// The multipart structure and boundaries are kept. Top-level headers keep their order
+// and unchanged ones are written byte for byte; part headers are written in sorted
+// order. Multipart preambles and epilogues are dropped.
+//
+// Example:
+//
+//	err := s.SanitizeMIME(inbound, &stored)
+func (s *Sanitizer) SanitizeMIME(r io.Reader, w io.Writer) error {
+	out := bufio.NewWriter(w)
+	err := s.sanitizeMIMEMessage(bufio.NewReader(r), out)
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// mimeHeaderField is a header line, with its folded continuation lines
+type mimeHeaderField struct {
+	name  string
+	value string // unfolded and trimmed
+	raw   string // as read, including line endings
+}
+
+// sanitizeMIMEMessage sanitizes a message: its header block, then its body
+func (s *Sanitizer) sanitizeMIMEMessage(br *bufio.Reader, w io.Writer) error {
+	fields, separator, err := readMIMEHeader(br)
+	if err != nil {
+		return err
+	}
+
+	header := make(textproto.MIMEHeader, len(fields))
+	for _, f := range fields {
+		header.Add(f.name, f.value)
+	}
+	var flag string
+	if isMIMEAttachment(header) {
+		flag = MIMEAttachmentHeader + ": " + removedAttachment(header, br) + "\r\n"
+	}
+
+	for _, f := range fields {
+		value, keep := s.sanitizeMIMEHeader(f.name, f.value)
+		switch {
+		case !keep:
+			// Dropped under StrategyRemove
+		case value == f.value:
+			io.WriteString(w, f.raw)
+		default:
+			io.WriteString(w, f.name+": "+value+"\r\n")
+		}
+	}
+	io.WriteString(w, flag)
+	io.WriteString(w, separator)
+
+	if flag != "" {
+		return nil
+	}
+	return s.sanitizeMIMEBody(header, br, w)
+}
+
+// readMIMEHeader reads a header block up to and including the blank line that ends
+// it, which is returned as the separator ("" if the input ends first)
+func readMIMEHeader(br *bufio.Reader) ([]mimeHeaderField, string, error) {
+	var fields []mimeHeaderField
+	for {
+		line, err := br.ReadString('\n')
+		if line == "" && err != nil {
+			if err == io.EOF {
+				return fields, "", nil
+			}
+			return nil, "", err
+		}
+
+		content := strings.TrimRight(line, "\r\n")
+		switch {
+		case content == "":
+			return fields, line, nil
+		case (content[0] == ' ' || content[0] == '\t') && len(fields) > 0:
+			last := &fields[len(fields)-1]
+			last.raw += line
+			last.value = strings.TrimSpace(last.value + content)
+		default:
+			name, value, found := strings.Cut(content, ":")
+			if !found {
+				return nil, "", errMalformedMIMEHeader
+			}
+			fields = append(fields, mimeHeaderField{
+				name:  strings.TrimSpace(name),
+				value: strings.TrimSpace(value),
+				raw:   line,
+			})
+		}
+		if err == io.EOF {
+			return fields, "", nil
+		}
+	}
+}
+
+// sanitizeMIMEHeader sanitizes a header value by header semantics.
+// Returns false if the header should be dropped (StrategyRemove).
+func (s *Sanitizer) sanitizeMIMEHeader(name, value string) (string, bool) {
+	canonical := textproto.CanonicalMIMEHeaderKey(name)
+
+	var sanitized string
+	switch {
+	case mimeKeptHeaders[canonical]:
+		return value, true
+	case mimeAddressHeaders[canonical]:
+		sanitized = s.sanitizeAddressList(value)
+	case mimeTextHeaders[canonical]:
+		sanitized = s.sanitizeEncodedText(value)
+	case canonical == "Content-Type" || canonical == "Content-Disposition":
+		sanitized = s.sanitizeMediaParams(value)
+	default:
+		sanitized = s.sanitizeHeaderValue(canonical, value)
+	}
+	return sanitized, !s.isRemoved(value, sanitized)
+}
+
+// sanitizeAddressList redacts the display names and addresses of an address list
+func (s *Sanitizer) sanitizeAddressList(value string) string {
+	addresses, err := mail.ParseAddressList(value)
+	if err != nil {
+		return s.sanitizeEncodedText(value)
+	}
+
+	formatted := make([]string, 0, len(addresses))
+	for _, a := range addresses {
+		name := a.Name
+		if name != "" {
+			name = s.redact(name)
+		}
+		address := s.redact(a.Address)
+		switch {
+		case address == "" && name == "":
+			continue
+		case name == "":
+			formatted = append(formatted, "<"+address+">")
+		default:
+			formatted = append(formatted, formatMailName(name)+" <"+address+">")
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// formatMailName quotes or encodes a display name as needed
+func formatMailName(name string) string {
+	for i := 0; i < len(name); i++ {
+		if name[i] >= 0x80 {
+			return mime.QEncoding.Encode("utf-8", name)
+		}
+	}
+	if strings.ContainsAny(name, `()<>[]:;@\,."`) {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(name) + `"`
+	}
+	return name
+}
+
+// sanitizeEncodedText redacts PII spans in a free text header, decoding RFC 2047
+// encoded words first. Unchanged values are returned as is.
+func (s *Sanitizer) sanitizeEncodedText(value string) string {
+	decoded, err := new(mime.WordDecoder).DecodeHeader(value)
+	if err != nil {
+		decoded = value
+	}
+	sanitized := s.sanitizeText(decoded)
+	if sanitized == decoded {
+		return value
+	}
+	for i := 0; i < len(sanitized); i++ {
+		if sanitized[i] >= 0x80 {
+			return mime.QEncoding.Encode("utf-8", sanitized)
+		}
+	}
+	return sanitized
+}
+
+// sanitizeMediaParams redacts PII spans in the name and filename parameters of a
+// Content-Type or Content-Disposition value
+func (s *Sanitizer) sanitizeMediaParams(value string) string {
+	mediaType, params, err := mime.ParseMediaType(value)
+	if err != nil {
+		return value
+	}
+
+	changed := false
+	for _, key := range []string{"name", "filename"} {
+		if param, ok := params[key]; ok {
+			if sanitized := s.sanitizeText(param); sanitized != param {
+				params[key] = sanitized
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return value
+	}
+	if formatted := mime.FormatMediaType(mediaType, params); formatted != "" {
+		return formatted
+	}
+	return value
+}
+
+// sanitizeMIMEBody sanitizes the body of a message or part with header h
+func (s *Sanitizer) sanitizeMIMEBody(h textproto.MIMEHeader, body io.Reader, w io.Writer) error {
+	mediaType, params, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "":
+		return s.sanitizeMultipart(body, w, params["boundary"])
+	case mediaType == "message/rfc822":
+		return s.sanitizeMIMEMessage(bufio.NewReader(body), w)
+	}
+
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	encoding := strings.ToLower(strings.TrimSpace(h.Get("Content-Transfer-Encoding")))
+	decoded, err := io.ReadAll(decodeTransferEncoding(bytes.NewReader(raw), encoding))
+	if err != nil {
+		return fmt.Errorf("sanitizer: decoding %s body: %w", encoding, err)
+	}
+
+	sanitized := s.sanitizeText(string(decoded))
+	if sanitized == string(decoded) {
+		_, err = w.Write(raw)
+		return err
+	}
+	return writeTransferEncoding(w, encoding, sanitized)
+}
+
+// sanitizeMultipart sanitizes each part of a multipart body, keeping its boundary
+func (s *Sanitizer) sanitizeMultipart(body io.Reader, w io.Writer, boundary string) error {
+	mr := multipart.NewReader(body, boundary)
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	for {
+		part, err := mr.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		header := make(textproto.MIMEHeader, len(part.Header)+1)
+		for name, values := range part.Header {
+			for _, value := range values {
+				if sanitized, keep := s.sanitizeMIMEHeader(name, value); keep {
+					header.Add(name, sanitized)
+				}
+			}
+		}
+
+		if isMIMEAttachment(part.Header) {
+			header.Set(MIMEAttachmentHeader, removedAttachment(part.Header, part))
+			if _, err := mw.CreatePart(header); err != nil {
+				return err
+			}
+			continue
+		}
+
+		pw, err := mw.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if err := s.sanitizeMIMEBody(part.Header, part, pw); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+// isMIMEAttachment reports whether a part is an attachment or other non-text content
+func isMIMEAttachment(h textproto.MIMEHeader) bool {
+	if disposition, _, _ := mime.ParseMediaType(h.Get("Content-Disposition")); disposition == "attachment" {
+		return true
+	}
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	switch {
+	case mediaType == "", strings.HasPrefix(mediaType, "text/"), strings.HasPrefix(mediaType, "multipart/"), mediaType == "message/rfc822":
+		return false
+	}
+	return true
+}
+
+// removedAttachment discards an attachment body and returns the MIMEAttachmentHeader
+// value describing it
+func removedAttachment(h textproto.MIMEHeader, body io.Reader) string {
+	encoding := strings.ToLower(strings.TrimSpace(h.Get("Content-Transfer-Encoding")))
+	n, _ := io.Copy(io.Discard, decodeTransferEncoding(body, encoding))
+	return "removed; size=" + strconv.FormatInt(n, 10)
+}
+
+// decodeTransferEncoding decodes a quoted-printable or base64 body; other
+// encodings (7bit, 8bit, binary) are returned as is
+func decodeTransferEncoding(r io.Reader, encoding string) io.Reader {
+	switch encoding {
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	}
+	return r
+}
+
+// writeTransferEncoding writes text in a Content-Transfer-Encoding
+func writeTransferEncoding(w io.Writer, encoding, text string) error {
+	switch encoding {
+	case "quoted-printable":
+		qw := quotedprintable.NewWriter(w)
+		if _, err := io.WriteString(qw, text); err != nil {
+			return err
+		}
+		return qw.Close()
+	case "base64":
+		encoded := base64.StdEncoding.EncodeToString([]byte(text))
+		for len(encoded) > 0 {
+			line := encoded[:min(len(encoded), 76)]
+			if _, err := io.WriteString(w, line+"\r\n"); err != nil {
+				return err
+			}
+			encoded = encoded[len(line):]
+		}
+		return nil
+	}
+	_, err := io.WriteString(w, text)
+	return err
+}
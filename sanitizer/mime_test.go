@@ -0,0 +1,220 @@
+package sanitizer
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+const supportEmail = "Return-Path: <john@acme.com>\r\n" +
+	"Received: from mail.acme.com by mx.example.com\r\n" +
+	"\tfor <support@example.com>; Fri, 18 Oct 2024 10:00:00 +0800\r\n" +
+	"From: \"John Tan\" <john@acme.com>\r\n" +
+	"To: Support <support@example.com>, jane@acme.com\r\n" +
+	"Subject: =?utf-8?q?Refund_for_card_4111111111111111?=\r\n" +
+	"Date: Fri, 18 Oct 2024 10:00:00 +0800\r\n" +
+	"Message-ID: <abc123@mail.acme.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"outer\"\r\n" +
+	"\r\n" +
+	"This is a multi-part message in MIME format.\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"inner\"\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Hi, please call me on +6591234567 or reply to john@acme.com.=0D=0AOrder ORD-1=\r\n" +
+	" is late.\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"PHA+Q2FsbCA8Yj4rNjU5MTIzNDU2NzwvYj4gb3IgPGEgaHJlZj0ibWFpbHRvOmpvaG5AYWNtZS5j\r\n" +
+	"b20iPmVtYWlsPC9hPjwvcD4=\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: application/pdf; name=\"john@acme.com statement.pdf\"\r\n" +
+	"Content-Disposition: attachment; filename=\"john@acme.com statement.pdf\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBERi0xLjQK\r\n" +
+	"--outer--\r\n"
+
+// readMIMEParts returns the parts of a multipart body depth first, with each leaf's
+// decoded content in the X-Test-Body header
+func readMIMEParts(t *testing.T, body io.Reader, boundary string) []*multipart.Part {
+	t.Helper()
+	var parts []*multipart.Part
+	mr := multipart.NewReader(body, boundary)
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("NextPart() error = %v", err)
+		}
+		parts = append(parts, part)
+		if _, params, _ := mime.ParseMediaType(part.Header.Get("Content-Type")); params["boundary"] != "" {
+			parts = append(parts, readMIMEParts(t, part, params["boundary"])...)
+		} else {
+			data, _ := io.ReadAll(decodeTransferEncoding(part, part.Header.Get("Content-Transfer-Encoding")))
+			part.Header.Set("X-Test-Body", string(data))
+		}
+	}
+}
+
+func TestSanitizeMIME(t *testing.T) {
+	s := NewDefault()
+
+	var out bytes.Buffer
+	if err := s.SanitizeMIME(strings.NewReader(supportEmail), &out); err != nil {
+		t.Fatalf("SanitizeMIME() error = %v", err)
+	}
+	if strings.Contains(out.String(), "john@acme.com") || strings.Contains(out.String(), "6591234567") {
+		t.Fatalf("Expected PII to be redacted, got:\n%s", out.String())
+	}
+
+	msg, err := mail.ReadMessage(&out)
+	if err != nil {
+		t.Fatalf("Sanitized message does not parse: %v", err)
+	}
+
+	expectedHeaders := map[string]string{
+		"Return-Path": "<[REDACTED]>",
+		"Received":    "from mail.acme.com by mx.example.com\tfor <[REDACTED]>; Fri, 18 Oct 2024 10:00:00 +0800",
+		"From":        `"[REDACTED]" <[REDACTED]>`,
+		"To":          `"[REDACTED]" <[REDACTED]>, <[REDACTED]>`,
+		"Subject":     "Refund for card [REDACTED]",
+		"Date":        "Fri, 18 Oct 2024 10:00:00 +0800",
+		"Message-Id":  "<abc123@mail.acme.com>",
+	}
+	for name, expected := range expectedHeaders {
+		if got := msg.Header.Get(name); got != expected {
+			t.Errorf("%s: got %q, want %q", name, got, expected)
+		}
+	}
+
+	_, params, _ := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if params["boundary"] != "outer" {
+		t.Fatalf("Expected boundary to be kept, got %q", params["boundary"])
+	}
+	parts := readMIMEParts(t, msg.Body, "outer")
+	if len(parts) != 4 {
+		t.Fatalf("Expected 4 parts (alternative, text, html, attachment), got %d", len(parts))
+	}
+
+	text, html, attachment := parts[1], parts[2], parts[3]
+	if got := text.Header.Get("X-Test-Body"); got != "Hi, please call me on [REDACTED] or reply to [REDACTED].\r\nOrder ORD-1 is late." {
+		t.Errorf("text body: got %q", got)
+	}
+	if got := html.Header.Get("X-Test-Body"); got != `<p>Call <b>[REDACTED]</b> or <a href="mailto:[REDACTED]">email</a></p>` {
+		t.Errorf("html body: got %q", got)
+	}
+	if html.Header.Get("Content-Transfer-Encoding") != "base64" {
+		t.Error("Expected transfer encoding to be kept")
+	}
+
+	if got := attachment.Header.Get(MIMEAttachmentHeader); got != "removed; size=9" {
+		t.Errorf("Expected attachment to be flagged, got %q", got)
+	}
+	if got := attachment.Header.Get("X-Test-Body"); got != "" {
+		t.Errorf("Expected attachment content to be removed, got %q", got)
+	}
+	if got := attachment.FileName(); got != "[REDACTED] statement.pdf" {
+		t.Errorf("Expected sanitized file name, got %q", got)
+	}
+}
+
+func TestSanitizeMIME_SinglePart(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "unchanged headers kept byte for byte",
+			input:    "X-Mailer: Test\r\nSubject: Order ORD-1\r\n\tis late\r\n\r\nThanks\r\n",
+			expected: "X-Mailer: Test\r\nSubject: Order ORD-1\r\n\tis late\r\n\r\nThanks\r\n",
+		},
+		{
+			name:     "plain body",
+			input:    "Subject: hello\nCc: jane@acme.com\n\nmy NRIC is S1234567D\n",
+			expected: "Subject: hello\nCc: <[REDACTED]>\r\n\nmy NRIC is [REDACTED]\n",
+		},
+		{
+			name:     "encoded subject stays encoded",
+			input:    "Subject: =?utf-8?q?Caf=C3=A9_john=40acme.com?=\r\n\r\n",
+			expected: "Subject: =?utf-8?q?Caf=C3=A9_[REDACTED]?=\r\n\r\n",
+		},
+		{
+			name:     "forwarded message",
+			input:    "Content-Type: message/rfc822\r\n\r\nFrom: jane@acme.com\r\n\r\nsee +6591234567\r\n",
+			expected: "Content-Type: message/rfc822\r\n\r\nFrom: <[REDACTED]>\r\n\r\nsee [REDACTED]\r\n",
+		},
+		{
+			name:     "top-level attachment",
+			input:    "Content-Type: image/png\r\n\r\n\x89PNG",
+			expected: "Content-Type: image/png\r\n" + MIMEAttachmentHeader + ": removed; size=4\r\n\r\n",
+		},
+		{
+			name:     "headers only",
+			input:    "From: jane@acme.com\r\n",
+			expected: "From: <[REDACTED]>\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := s.SanitizeMIME(strings.NewReader(tt.input), &out); err != nil {
+				t.Fatalf("SanitizeMIME() error = %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("got  %q\nwant %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestSanitizeMIME_RemoveStrategy(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyRemove))
+
+	var out bytes.Buffer
+	input := "From: John Tan <john@acme.com>\r\nSubject: hi\r\n\r\nbody\r\n"
+	if err := s.SanitizeMIME(strings.NewReader(input), &out); err != nil {
+		t.Fatalf("SanitizeMIME() error = %v", err)
+	}
+	if expected := "Subject: hi\r\n\r\nbody\r\n"; out.String() != expected {
+		t.Errorf("got %q, want %q", out.String(), expected)
+	}
+}
+
+func TestSanitizeMIME_Errors(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"malformed header", "not a header\r\n\r\nbody"},
+		{"bad base64", "Content-Transfer-Encoding: base64\r\n\r\n!!!!"},
+		{"unterminated multipart", "Content-Type: multipart/mixed; boundary=b\r\n\r\n--b\r\nContent-Type: text/plain\r\n\r\nhi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.SanitizeMIME(strings.NewReader(tt.input), io.Discard); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -4,7 +4,11 @@ import "regexp"
 
 // ContentPattern defines a pattern for detecting PII in field content
 type ContentPattern struct {
-	Name      string
+	Name string
+
+	// Pattern matches the PII value. If it has a group named "value" ((?P<value>...)),
+	// only that group is the value and the rest of the match is context that is not
+	// redacted, such as a delimiter.
 	Pattern   *regexp.Regexp
 	Validator func(string) bool // Optional validation function (e.g., Luhn for credit cards)
 }
@@ -24,5 +28,20 @@ func getAllRegionalPatterns() []RegionalPatterns {
 		getUAEPatterns(),
 		getThailandPatterns(),
 		getHongKongPatterns(),
+		getIndiaPatterns(),
+		getSaudiArabiaPatterns(),
+		getQatarPatterns(),
+		getKuwaitPatterns(),
+		getBahrainPatterns(),
+		getOmanPatterns(),
+		getChinaPatterns(),
+		getJapanPatterns(),
+		getAustraliaPatterns(),
+		getUnitedKingdomPatterns(),
+		getGermanyPatterns(),
+		getFrancePatterns(),
+		getSpainPatterns(),
+		getItalyPatterns(),
+		getNetherlandsPatterns(),
 	}
 }
@@ -0,0 +1,101 @@
+package sanitizer
+
+import "regexp"
+
+// validateTFN validates an Australian Tax File Number weighted checksum
+func validateTFN(tfn string) bool {
+	digits := extractDigits(tfn)
+	if len(digits) != 9 {
+		return false
+	}
+
+	weights := []int{1, 4, 3, 7, 5, 8, 6, 9, 10}
+	sum := 0
+	for i, weight := range weights {
+		sum += digits[i] * weight
+	}
+
+	return sum%11 == 0
+}
+
+// validateMedicare validates an Australian Medicare card number check digit.
+// 10 digits (optionally followed by a 1-digit Individual Reference Number), first digit 2-6.
+func validateMedicare(medicare string) bool {
+	digits := extractDigits(medicare)
+	if len(digits) != 10 && len(digits) != 11 {
+		return false
+	}
+
+	if digits[0] < 2 || digits[0] > 6 {
+		return false
+	}
+
+	weights := []int{1, 3, 7, 9, 1, 3, 7, 9}
+	sum := 0
+	for i, weight := range weights {
+		sum += digits[i] * weight
+	}
+
+	return sum%10 == digits[8]
+}
+
+// validateABN validates an Australian Business Number checksum.
+// Subtract 1 from the first digit, apply weights, and the sum must be divisible by 89.
+func validateABN(abn string) bool {
+	digits := extractDigits(abn)
+	if len(digits) != 11 {
+		return false
+	}
+
+	weights := []int{10, 1, 3, 5, 7, 9, 11, 13, 15, 17, 19}
+	sum := 0
+	for i, weight := range weights {
+		digit := digits[i]
+		if i == 0 {
+			digit--
+		}
+		sum += digit * weight
+	}
+
+	return sum%89 == 0
+}
+
+// getAustraliaPatterns returns PII patterns for Australia
+func getAustraliaPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: Australia,
+		FieldNames: []string{
+			"tfn", "taxFileNumber", "tax_file_number",
+			"medicare", "medicareNumber", "medicare_number", "medicareCard", "medicare_card",
+			"abn", "australianBusinessNumber",
+			"bsb", "accountNumber", "account_number", "bankAccount", "bank_account",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "australia_tfn",
+				// Format: XXX XXX XXX (9 digits, weighted mod-11 checksum)
+				Pattern:   regexp.MustCompile(`\b\d{3}[\s-]?\d{3}[\s-]?\d{3}\b`),
+				Validator: validateTFN,
+			},
+			{
+				Name: "australia_medicare",
+				// Format: XXXX XXXXX X[-N] (first digit 2-6, 9th digit is a check digit, optional IRN)
+				Pattern:   regexp.MustCompile(`\b[2-6]\d{3}[\s-]?\d{5}[\s-]?\d(?:[\s-/]?[1-9])?\b`),
+				Validator: validateMedicare,
+			},
+			{
+				Name: "australia_abn",
+				// ABNs are public business identifiers that look like any 11-digit number,
+				// so they are only matched when labelled as an ABN
+				Pattern:   regexp.MustCompile(`(?i)\bABN[\s:#]*\d{2}\s?\d{3}\s?\d{3}\s?\d{3}\b`),
+				Validator: validateABN,
+			},
+			{
+				Name: "australia_phone",
+				// Mobile: +61 / 0061 + 4XX XXX XXX, or domestic 04XX XXX XXX
+				Pattern: regexp.MustCompile(`(?:(?:\+61|\b0061)[\s-]?|\b0)4\d{2}[\s-]?\d{3}[\s-]?\d{3}\b`),
+			},
+			// NOTE: Bank account content pattern omitted - use field name matching only
+		},
+	}
+}
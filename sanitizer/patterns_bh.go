@@ -0,0 +1,34 @@
+package sanitizer
+
+import "regexp"
+
+// getBahrainPatterns returns PII patterns for Bahrain
+func getBahrainPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: Bahrain,
+		FieldNames: []string{
+			"cpr", "cprNumber", "cpr_number", "bahrainId", "bahrain_id",
+			"nationalId", "national_id", "identityCard", "identity_card",
+			"iban", "accountNumber", "account_number", "bankAccount", "bank_account",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "bahrain_cpr",
+				// Format: 9 digits (YYMM + serial + check digit)
+				// A bare 9-digit number is too common, so CPRs are only matched next to a CPR keyword
+				Pattern: regexp.MustCompile(`(?i)\bCPR\s?(?:No\.?|Number)?[\s:#-]*\d{2}(?:0[1-9]|1[0-2])\d{5}\b`),
+			},
+			{
+				Name: "bahrain_phone",
+				// Phone: +973 / 00973 + 8 digits (mobile: 3 prefix, landline: 1 prefix)
+				Pattern: regexp.MustCompile(`(?:\+973|\b00973)[\s-]?[13]\d{3}[\s-]?\d{4}\b`),
+			},
+			{
+				Name: "bahrain_iban",
+				// IBAN: BH + 2 check digits + 4-letter bank code + 14 chars (22 chars total)
+				Pattern:   ibanPattern("BH", 18),
+				Validator: validateIBAN,
+			},
+		},
+	}
+}
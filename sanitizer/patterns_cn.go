@@ -0,0 +1,90 @@
+package sanitizer
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// chinaProvinceCodes lists the first two digits of valid GB/T 2260 administrative division codes
+var chinaProvinceCodes = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true, // North China
+	"21": true, "22": true, "23": true, // Northeast
+	"31": true, "32": true, "33": true, "34": true, "35": true, "36": true, "37": true, // East China
+	"41": true, "42": true, "43": true, "44": true, "45": true, "46": true, // South Central
+	"50": true, "51": true, "52": true, "53": true, "54": true, // Southwest
+	"61": true, "62": true, "63": true, "64": true, "65": true, // Northwest
+	"71": true, "81": true, "82": true, // Taiwan, Hong Kong, Macau
+	"83": true, // Residence permits for Taiwan, Hong Kong and Macau residents
+}
+
+// validateChinaResidentID validates an 18-digit resident identity number (GB 11643-1999).
+// Checks the administrative division prefix, embedded birth date and ISO 7064 MOD 11-2 check character.
+func validateChinaResidentID(id string) bool {
+	id = strings.ToUpper(id)
+	if len(id) != 18 {
+		return false
+	}
+
+	if !chinaProvinceCodes[id[0:2]] {
+		return false
+	}
+
+	// Birth date: YYYYMMDD at positions 7-14
+	birth, err := time.Parse("20060102", id[6:14])
+	if err != nil || birth.Year() < 1900 || birth.After(time.Now()) {
+		return false
+	}
+
+	weights := []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	sum := 0
+	for i, weight := range weights {
+		digit := id[i] - '0'
+		if digit > 9 {
+			return false
+		}
+		sum += int(digit) * weight
+	}
+
+	checkChars := "10X98765432"
+	return id[17] == checkChars[sum%11]
+}
+
+// getChinaPatterns returns PII patterns for mainland China
+func getChinaPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: China,
+		FieldNames: []string{
+			"residentId", "resident_id", "idNumber", "id_number", "shenfenzheng",
+			"chinaId", "china_id", "identityCard", "identity_card",
+			"homeReturnPermit", "home_return_permit", "hrp",
+			"birNumber", "bir_number", "macauId", "macau_id",
+			"accountNumber", "account_number", "bankAccount", "bank_account",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "china_resident_id",
+				// Format: 6-digit division code + YYYYMMDD + 3-digit sequence + check character (0-9 or X)
+				Pattern:   regexp.MustCompile(`(?i)\b\d{17}[\dX]\b`),
+				Validator: validateChinaResidentID,
+			},
+			{
+				Name: "china_home_return_permit",
+				// Format: H (Hong Kong) or M (Macau) + 8 digits (older cards: + 10 digits)
+				// No public checksum, so only matched next to a permit keyword
+				Pattern: regexp.MustCompile(`(?i)(?:\bHome\s?Return\s?Permit|\bHRP|回乡证|回鄉證|通行证|通行證)\s?(?:No\.?|Number)?[\s:：#-]*[HM]\d{8}(?:\d{2})?\b`),
+			},
+			{
+				Name: "macau_bir",
+				// Macau resident identity card: 1/234567/8 or 1234567(8), first digit 1, 5 or 7
+				Pattern: regexp.MustCompile(`\b[157]/\d{6}/\d\b|\b[157]\d{6}\(\d\)`),
+			},
+			{
+				Name: "china_phone",
+				// Phone: +86 / 0086 + 11-digit mobile number starting with 13-19
+				Pattern: regexp.MustCompile(`(?:\+86|\b0086)[\s-]?1[3-9]\d[\s-]?\d{4}[\s-]?\d{4}\b`),
+			},
+			// NOTE: Bank account content pattern omitted - use field name matching only
+		},
+	}
+}
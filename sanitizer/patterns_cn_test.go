@@ -0,0 +1,67 @@
+package sanitizer
+
+import (
+	"testing"
+)
+
+func TestSanitizeField_China(t *testing.T) {
+	s := NewForRegion(China)
+
+	tests := []struct {
+		name       string
+		fieldName  string
+		value      string
+		shouldMask bool
+	}{
+		{"Resident ID with X check character", "text", "ID 11010519491231002X", true},
+		{"Resident ID lowercase x", "text", "11010519491231002x", true},
+		{"Resident ID numeric check", "text", "440308199001011239", true},
+		{"Resident ID leap day", "text", "310115200002290048", true},
+		{"Resident ID bad check character", "text", "440308199001011238", false},
+		{"Resident ID unknown division", "text", "990308199001011236", false},
+		{"Resident ID invalid birth date", "text", "440308199002301234", false},
+		{"Home Return Permit with keyword", "text", "Home Return Permit: H12345678", true},
+		{"Home Return Permit Chinese keyword", "text", "回乡证 H1234567890", true},
+		{"Bare permit-like code", "text", "H12345678", false},
+		{"Macau BIR with slashes", "text", "BIR 1/234567/8", true},
+		{"Macau BIR with parentheses", "text", "5234567(3)", true},
+		{"Phone with +86", "text", "+86 138 1234 5678", true},
+		{"Phone with 0086", "text", "008613812345678", true},
+		{"Phone invalid prefix", "text", "+86 120 1234 5678", false},
+		{"Resident ID field name", "residentId", "anything", true},
+		{"Home Return Permit field name", "homeReturnPermit", "H12345678", true},
+		{"Non-PII", "orderId", "ORD-12345", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.SanitizeField(tt.fieldName, tt.value)
+			if tt.shouldMask && result != "[REDACTED]" {
+				t.Errorf("Expected [REDACTED], got: %s", result)
+			}
+			if !tt.shouldMask && result != tt.value {
+				t.Errorf("Expected value to be preserved, but got: %s", result)
+			}
+		})
+	}
+}
+
+func TestValidateChinaResidentID(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"11010519491231002X", true},
+		{"440308199001011239", true},
+		{"11010518991231002X", false}, // born before 1900
+		{"440308299001011239", false}, // born in the future
+		{"4403081990010112", false},   // too short
+		{"44030819900101A239", false}, // non-digit body
+	}
+
+	for _, tt := range tests {
+		if got := validateChinaResidentID(tt.id); got != tt.valid {
+			t.Errorf("validateChinaResidentID(%q) = %v, expected %v", tt.id, got, tt.valid)
+		}
+	}
+}
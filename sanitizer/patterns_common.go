@@ -1,6 +1,10 @@
 package sanitizer
 
-import "regexp"
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
 
 // getCommonFieldNames returns field name patterns for common PII types
 // Priority order based on user requirements
@@ -98,18 +102,29 @@ func getCommonContentPatterns() []ContentPattern {
 // validateLuhn validates a credit card number using the Luhn algorithm
 func validateLuhn(cardNumber string) bool {
 	// Remove spaces, dashes, and any non-digit characters
-	var digits []int
-	for _, r := range cardNumber {
-		if r >= '0' && r <= '9' {
-			digits = append(digits, int(r-'0'))
-		}
-	}
+	digits := extractDigits(cardNumber)
 
 	// Credit cards are typically 13-19 digits
 	if len(digits) < 13 || len(digits) > 19 {
 		return false
 	}
 
+	return luhnValid(digits)
+}
+
+// extractDigits returns the decimal digits of s, skipping separators and other characters
+func extractDigits(s string) []int {
+	digits := make([]int, 0, len(s))
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, int(r-'0'))
+		}
+	}
+	return digits
+}
+
+// luhnValid runs the Luhn (mod 10) checksum over digits, the last being the check digit
+func luhnValid(digits []int) bool {
 	// Luhn algorithm: start from rightmost digit (check digit)
 	sum := 0
 	parity := len(digits) % 2
@@ -130,3 +145,33 @@ func validateLuhn(cardNumber string) bool {
 
 	return sum%10 == 0
 }
+
+// validateIBAN validates an IBAN using the ISO 13616 mod-97 check
+func validateIBAN(iban string) bool {
+	iban = strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+
+	// Move country code and check digits to the end, then map letters to 10..35
+	rearranged := iban[4:] + iban[:4]
+	remainder := 0
+	for _, r := range rearranged {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+
+	return remainder == 1
+}
+
+// ibanPattern builds a content pattern for a country's IBAN.
+// bbanLength is the number of characters after the check digits; spaces between characters are allowed.
+func ibanPattern(countryCode string, bbanLength int) *regexp.Regexp {
+	return regexp.MustCompile(`\b` + countryCode + `\d{2}(?:\s?[0-9A-Z]){` + strconv.Itoa(bbanLength) + `}\b`)
+}
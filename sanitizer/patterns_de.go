@@ -0,0 +1,80 @@
+package sanitizer
+
+import "regexp"
+
+// validateSteuerID validates a German tax identification number (Steuerliche Identifikationsnummer).
+// 11 digits, no leading zero, exactly one repeated digit in the first 10, ISO 7064 MOD 11,10 check digit.
+func validateSteuerID(id string) bool {
+	digits := extractDigits(id)
+	if len(digits) != 11 || digits[0] == 0 {
+		return false
+	}
+
+	// Exactly one digit appears two or three times; three occurrences must not be consecutive
+	var counts [10]int
+	for _, d := range digits[:10] {
+		counts[d]++
+	}
+	repeated := 0
+	for d, count := range counts {
+		switch {
+		case count == 2:
+			repeated++
+		case count == 3:
+			repeated++
+			for i := 0; i+2 < 10; i++ {
+				if digits[i] == d && digits[i+1] == d && digits[i+2] == d {
+					return false
+				}
+			}
+		case count > 3:
+			return false
+		}
+	}
+	if repeated != 1 {
+		return false
+	}
+
+	product := 10
+	for _, d := range digits[:10] {
+		sum := (d + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = (sum * 2) % 11
+	}
+
+	check := 11 - product
+	if check == 10 {
+		check = 0
+	}
+
+	return check == digits[10]
+}
+
+// getGermanyPatterns returns PII patterns for Germany
+func getGermanyPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: Germany,
+		FieldNames: []string{
+			"steuerId", "steuer_id", "steuerIdentifikationsnummer", "idnr", "taxId", "tax_id",
+			"personalausweis", "personalausweisnummer", "ausweisnummer",
+			"vorname", "nachname", "geburtsdatum", "anschrift",
+			"iban", "accountNumber", "account_number", "bankAccount", "bank_account", "kontonummer",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "germany_steuer_id",
+				// Format: 11 digits, often written 12 345 678 901
+				Pattern:   regexp.MustCompile(`\b[1-9]\d\s?\d{3}\s?\d{3}\s?\d{3}\b`),
+				Validator: validateSteuerID,
+			},
+			{
+				Name: "germany_iban",
+				// IBAN: DE + 2 check digits + 18 digits (22 chars total)
+				Pattern:   ibanPattern("DE", 18),
+				Validator: validateIBAN,
+			},
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package sanitizer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// validateSpanishID validates a Spanish DNI or NIE control letter.
+// DNI: 8 digits + letter. NIE: X/Y/Z + 7 digits + letter, with X=0, Y=1, Z=2.
+func validateSpanishID(id string) bool {
+	id = strings.ToUpper(strings.ReplaceAll(id, "-", ""))
+
+	switch {
+	case len(id) == 9 && strings.IndexByte("XYZ", id[0]) >= 0:
+		id = strconv.Itoa(strings.IndexByte("XYZ", id[0])) + id[1:]
+	case len(id) != 9:
+		return false
+	}
+
+	number, err := strconv.Atoi(id[:8])
+	if err != nil {
+		return false
+	}
+
+	controlLetters := "TRWAGMYFPDXBNJZSQVHLCKE"
+	return id[8] == controlLetters[number%23]
+}
+
+// getSpainPatterns returns PII patterns for Spain
+func getSpainPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: Spain,
+		FieldNames: []string{
+			"dni", "nie", "nif", "documentoIdentidad", "documento_identidad",
+			"nombre", "apellido", "apellidos", "fechaNacimiento", "fecha_nacimiento", "direccion",
+			"iban", "accountNumber", "account_number", "bankAccount", "bank_account",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "spain_dni_nie",
+				// DNI: 12345678Z, NIE: X1234567L (control letter is number mod 23)
+				Pattern:   regexp.MustCompile(`(?i)\b(?:\d{8}|[XYZ]\d{7})-?[A-Z]\b`),
+				Validator: validateSpanishID,
+			},
+			{
+				Name: "spain_iban",
+				// IBAN: ES + 2 check digits + 20 digits (24 chars total)
+				Pattern:   ibanPattern("ES", 20),
+				Validator: validateIBAN,
+			},
+		},
+	}
+}
@@ -0,0 +1,109 @@
+package sanitizer
+
+import (
+	"testing"
+)
+
+func TestSanitizeField_UKAndEU(t *testing.T) {
+	tests := []struct {
+		name       string
+		region     Region
+		fieldName  string
+		value      string
+		shouldMask bool
+	}{
+		// United Kingdom
+		{"UK NINO", UnitedKingdom, "text", "NINO: AB 12 34 56 C", true},
+		{"UK NINO compact", UnitedKingdom, "text", "ab123456c", true},
+		{"UK NINO invalid first letter", UnitedKingdom, "text", "DA123456C", false},
+		{"UK NINO invalid second letter", UnitedKingdom, "text", "AO123456C", false},
+		{"UK NINO disallowed prefix", UnitedKingdom, "text", "GB123456A", false},
+		{"UK NINO invalid suffix", UnitedKingdom, "text", "AB123456E", false},
+		{"UK NHS number", UnitedKingdom, "text", "NHS 943 476 5919", true},
+		{"UK NHS number bad check digit", UnitedKingdom, "text", "9434765918", false},
+		{"UK IBAN", UnitedKingdom, "text", "GB82 WEST 1234 5698 7654 32", true},
+		{"UK NINO field name", UnitedKingdom, "nationalInsuranceNumber", "anything", true},
+
+		// Germany
+		{"German Steuer-ID", Germany, "text", "Steuer-ID 86095742719", true},
+		{"German Steuer-ID grouped", Germany, "text", "86 095 742 719", true},
+		{"German Steuer-ID bad check digit", Germany, "text", "86095742718", false},
+		{"German Steuer-ID two repeated digits", Germany, "text", "65234567892", false},
+		{"German IBAN", Germany, "text", "DE89 3704 0044 0532 0130 00", true},
+		{"German name field", Germany, "nachname", "Müller", true},
+
+		// France
+		{"French NIR", France, "text", "NIR 1 85 05 78 006 084 91", true},
+		{"French NIR compact", France, "text", "185057800608491", true},
+		{"French NIR Corsica 2A", France, "text", "2 69 05 2A 123 456 88", true},
+		{"French NIR Corsica 2B", France, "text", "269052B12345618", true},
+		{"French NIR bad key", France, "text", "185057800608492", false},
+		{"French NIR invalid month", France, "text", "185158000608491", false},
+		{"French IBAN", France, "text", "FR14 2004 1010 0505 0001 3M02 606", true},
+		{"French NIR field name", France, "numeroSecu", "anything", true},
+
+		// Spain
+		{"Spanish DNI", Spain, "text", "DNI 12345678Z", true},
+		{"Spanish DNI with dash", Spain, "text", "12345678-Z", true},
+		{"Spanish DNI bad letter", Spain, "text", "12345678A", false},
+		{"Spanish NIE", Spain, "text", "NIE X1234567L", true},
+		{"Spanish NIE bad letter", Spain, "text", "X1234567A", false},
+		{"Spanish IBAN", Spain, "text", "ES91 2100 0418 4502 0005 1332", true},
+		{"Spanish DNI field name", Spain, "dni", "anything", true},
+
+		// Italy
+		{"Italian Codice Fiscale", Italy, "text", "CF: RSSMRA85T10A562S", true},
+		{"Italian Codice Fiscale lowercase", Italy, "text", "rssmra85t10a562s", true},
+		{"Italian Codice Fiscale bad check", Italy, "text", "RSSMRA85T10A562T", false},
+		{"Italian IBAN", Italy, "text", "IT60 X054 2811 1010 0000 0123 456", true},
+		{"Italian name field", Italy, "cognome", "Rossi", true},
+
+		// Netherlands
+		{"Dutch BSN", Netherlands, "text", "BSN 111222333", true},
+		{"Dutch BSN dotted", Netherlands, "text", "1234.56.782", true},
+		{"Dutch BSN fails 11-proof", Netherlands, "text", "111222334", false},
+		{"Dutch IBAN", Netherlands, "text", "NL91 ABNA 0417 1643 00", true},
+		{"Dutch BSN field name", Netherlands, "burgerservicenummer", "anything", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewForRegion(tt.region)
+			result := s.SanitizeField(tt.fieldName, tt.value)
+			if tt.shouldMask && result != "[REDACTED]" {
+				t.Errorf("Expected [REDACTED], got: %s", result)
+			}
+			if !tt.shouldMask && result != tt.value {
+				t.Errorf("Expected value to be preserved, but got: %s", result)
+			}
+		})
+	}
+}
+
+func TestUKAndEU_Validators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator func(string) bool
+		value     string
+		valid     bool
+	}{
+		{"NINO too short", validateNINO, "AB12345C", false},
+		{"NHS too short", validateNHSNumber, "943476591", false},
+		{"Steuer-ID leading zero", validateSteuerID, "06095742719", false},
+		{"Steuer-ID digit three times in a row", validateSteuerID, "11123456789", false},
+		{"NIR wrong length", validateNIR, "18505780060849", false},
+		{"NIR non-numeric", validateNIR, "1850578A0608491", false},
+		{"Spanish ID wrong length", validateSpanishID, "1234567Z", false},
+		{"Codice Fiscale wrong length", validateCodiceFiscale, "RSSMRA85T10A562", false},
+		{"Codice Fiscale invalid character", validateCodiceFiscale, "RSSMRA85T10A56!S", false},
+		{"BSN all zeros", validateBSN, "000000000", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.validator(tt.value); got != tt.valid {
+				t.Errorf("validator(%q) = %v, expected %v", tt.value, got, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package sanitizer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// validateNIR validates a French social security number (NIR) and its 2-digit key.
+// Corsican departments 2A and 2B are substituted with 19 and 18 before computing the key.
+func validateNIR(nir string) bool {
+	nir = strings.ToUpper(strings.ReplaceAll(nir, " ", ""))
+	if len(nir) != 15 {
+		return false
+	}
+
+	// Month: 01-12, or 20 and 30-42 / 50-99 for people with an unknown or fictitious birth month
+	month, err := strconv.Atoi(nir[3:5])
+	if err != nil || month == 0 || (month > 12 && month != 20 && month < 30) || (month > 42 && month < 50) {
+		return false
+	}
+
+	body := nir[:13]
+	switch nir[5:7] {
+	case "2A":
+		body = nir[:5] + "19" + nir[7:13]
+	case "2B":
+		body = nir[:5] + "18" + nir[7:13]
+	}
+
+	number, err := strconv.ParseUint(body, 10, 64)
+	if err != nil {
+		return false
+	}
+	key, err := strconv.Atoi(nir[13:])
+	if err != nil {
+		return false
+	}
+
+	return int(97-number%97) == key
+}
+
+// getFrancePatterns returns PII patterns for France
+func getFrancePatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: France,
+		FieldNames: []string{
+			"nir", "numeroSecu", "numero_secu", "numeroSecuriteSociale", "numero_securite_sociale",
+			"securiteSociale", "securite_sociale", "insee",
+			"nom", "prenom", "nomDeNaissance", "dateDeNaissance", "date_de_naissance", "adresse",
+			"iban", "accountNumber", "account_number", "bankAccount", "bank_account", "rib",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "france_nir",
+				// Format: S YY MM DD CCC OOO KK (sex + birth year/month + department + commune + order + key)
+				Pattern:   regexp.MustCompile(`(?i)\b[12378]\s?\d{2}\s?\d{2}\s?(?:\d{2}|2[AB])\s?\d{3}\s?\d{3}\s?\d{2}\b`),
+				Validator: validateNIR,
+			},
+			{
+				Name: "france_iban",
+				// IBAN: FR + 2 check digits + 23 chars (27 chars total)
+				Pattern:   ibanPattern("FR", 23),
+				Validator: validateIBAN,
+			},
+		},
+	}
+}
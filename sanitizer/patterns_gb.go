@@ -0,0 +1,81 @@
+package sanitizer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// validateNINO validates a UK National Insurance number prefix and suffix rules
+func validateNINO(nino string) bool {
+	nino = strings.ToUpper(strings.ReplaceAll(nino, " ", ""))
+	if len(nino) != 9 {
+		return false
+	}
+
+	// D, F, I, Q, U and V are never used in either prefix letter; O is never the second letter
+	if strings.ContainsAny(nino[0:1], "DFIQUV") || strings.ContainsAny(nino[1:2], "DFIOQUV") {
+		return false
+	}
+
+	// Prefixes that are not allocated (BG, GB, KN, NK, NT, TN) or reserved for temporary numbers (ZZ)
+	switch nino[0:2] {
+	case "BG", "GB", "KN", "NK", "NT", "TN", "ZZ":
+		return false
+	}
+
+	return nino[8] >= 'A' && nino[8] <= 'D'
+}
+
+// validateNHSNumber validates an NHS number mod-11 check digit
+func validateNHSNumber(nhs string) bool {
+	digits := extractDigits(nhs)
+	if len(digits) != 10 {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += digits[i] * (10 - i)
+	}
+
+	check := 11 - sum%11
+	if check == 11 {
+		check = 0
+	}
+
+	// A check digit of 10 means the number is invalid
+	return check != 10 && check == digits[9]
+}
+
+// getUnitedKingdomPatterns returns PII patterns for the United Kingdom
+func getUnitedKingdomPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: UnitedKingdom,
+		FieldNames: []string{
+			"nino", "niNumber", "ni_number", "nationalInsuranceNumber", "national_insurance_number",
+			"nhs", "nhsNumber", "nhs_number",
+			"sortCode", "sort_code",
+			"iban", "accountNumber", "account_number", "bankAccount", "bank_account",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "uk_nino",
+				// Format: AB 12 34 56 C (2 prefix letters + 6 digits + suffix A-D)
+				Pattern:   regexp.MustCompile(`(?i)\b[A-Z]{2}\s?\d{2}\s?\d{2}\s?\d{2}\s?[A-D]\b`),
+				Validator: validateNINO,
+			},
+			{
+				Name: "uk_nhs_number",
+				// Format: 3-3-4 digits, last digit is a mod-11 check digit
+				Pattern:   regexp.MustCompile(`\b\d{3}[\s-]?\d{3}[\s-]?\d{4}\b`),
+				Validator: validateNHSNumber,
+			},
+			{
+				Name: "uk_iban",
+				// IBAN: GB + 2 check digits + 4-letter bank code + 14 digits (22 chars total)
+				Pattern:   ibanPattern("GB", 18),
+				Validator: validateIBAN,
+			},
+		},
+	}
+}
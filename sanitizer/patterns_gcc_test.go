@@ -0,0 +1,105 @@
+package sanitizer
+
+import (
+	"testing"
+)
+
+func TestSanitizeField_GCC(t *testing.T) {
+	tests := []struct {
+		name       string
+		region     Region
+		fieldName  string
+		value      string
+		shouldMask bool
+	}{
+		// Saudi Arabia
+		{"Saudi national ID", SaudiArabia, "text", "ID 1087654321", true},
+		{"Saudi Iqama", SaudiArabia, "text", "2345678904", true},
+		{"Saudi ID bad checksum", SaudiArabia, "text", "1087654322", false},
+		{"Saudi ID wrong prefix", SaudiArabia, "text", "3087654321", false},
+		{"Saudi phone", SaudiArabia, "text", "+966512345678", true},
+		{"Saudi local phone", SaudiArabia, "text", "0512345678", true},
+		{"Saudi IBAN", SaudiArabia, "text", "SA03 8000 0000 6080 1016 7519", true},
+		{"Saudi IBAN bad check digits", SaudiArabia, "text", "SA04 8000 0000 6080 1016 7519", false},
+		{"Iqama field name", SaudiArabia, "iqama", "anything", true},
+
+		// Qatar
+		{"Qatar QID with keyword", Qatar, "text", "QID: 28463401234", true},
+		{"Qatar ID with keyword", Qatar, "text", "Qatar ID 28463401234", true},
+		{"Qatar bare 11 digits", Qatar, "text", "28463401234", false},
+		{"Qatar phone", Qatar, "text", "+974 5512 3456", true},
+		{"Qatar IBAN", Qatar, "text", "QA58DOHB00001234567890ABCDEFG", true},
+		{"QID field name", Qatar, "qid", "28463401234", true},
+
+		// Kuwait
+		{"Kuwait civil ID", Kuwait, "text", "Civil ID 300123104568", true},
+		{"Kuwait civil ID bad checksum", Kuwait, "text", "300123104567", false},
+		{"Kuwait civil ID bad date", Kuwait, "text", "300133104568", false},
+		{"Kuwait phone", Kuwait, "text", "+96550012345", true},
+		{"Kuwait IBAN", Kuwait, "text", "KW81CBKU0000000000001234560101", true},
+		{"Civil ID field name", Kuwait, "civilId", "anything", true},
+
+		// Bahrain
+		{"Bahrain CPR with keyword", Bahrain, "text", "CPR No. 850112345", true},
+		{"Bahrain CPR invalid month", Bahrain, "text", "CPR 851312345", false},
+		{"Bahrain bare 9 digits", Bahrain, "text", "850112345", false},
+		{"Bahrain phone", Bahrain, "text", "+973 3600 1234", true},
+		{"Bahrain IBAN", Bahrain, "text", "BH67BMAG00001299123456", true},
+		{"CPR field name", Bahrain, "cpr", "850112345", true},
+
+		// Oman
+		{"Oman civil number with keyword", Oman, "text", "Civil No: 12345678", true},
+		{"Oman bare number", Oman, "text", "12345678", false},
+		{"Oman phone", Oman, "text", "+968 9123 4567", true},
+		{"Oman IBAN", Oman, "text", "OM810180000001299123456", true},
+		{"Oman civil number field name", Oman, "civil_number", "12345678", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewForRegion(tt.region)
+			result := s.SanitizeField(tt.fieldName, tt.value)
+			if tt.shouldMask && result != "[REDACTED]" {
+				t.Errorf("Expected [REDACTED], got: %s", result)
+			}
+			if !tt.shouldMask && result != tt.value {
+				t.Errorf("Expected value to be preserved, but got: %s", result)
+			}
+		})
+	}
+}
+
+func TestGCC_RegionsAreIndependent(t *testing.T) {
+	s := NewForRegion(SaudiArabia)
+
+	// Kuwait civil ID should NOT be detected (region not enabled)
+	if result := s.SanitizeField("text", "300123104568"); result != "300123104568" {
+		t.Errorf("Expected Kuwait civil ID to be preserved, got %s", result)
+	}
+
+	// Qatar phone should NOT be detected (region not enabled)
+	if result := s.SanitizeField("text", "+974 5512 3456"); result != "+974 5512 3456" {
+		t.Errorf("Expected Qatar phone to be preserved, got %s", result)
+	}
+}
+
+func TestValidateIBAN(t *testing.T) {
+	tests := []struct {
+		iban  string
+		valid bool
+	}{
+		{"SA0380000000608010167519", true},
+		{"sa03 8000 0000 6080 1016 7519", true},
+		{"AE070331234567890123456", true},
+		{"GB82WEST12345698765432", true},
+		{"GB82WEST12345698765433", false},
+		{"SA03", false},
+		{"SA03800000006080101675!9", false},
+	}
+
+	for _, tt := range tests {
+		if got := validateIBAN(tt.iban); got != tt.valid {
+			t.Errorf("validateIBAN(%q) = %v, expected %v", tt.iban, got, tt.valid)
+		}
+	}
+}
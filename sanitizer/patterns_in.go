@@ -0,0 +1,139 @@
+package sanitizer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Verhoeff algorithm tables (dihedral group D5)
+var (
+	verhoeffMultiplication = [10][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+		{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+		{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+		{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+		{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+		{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+		{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+		{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+	}
+	verhoeffPermutation = [8][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+		{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+		{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+		{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+		{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+		{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+		{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+	}
+)
+
+// validateVerhoeff validates a digit string whose last digit is a Verhoeff check digit
+func validateVerhoeff(number string) bool {
+	checksum := 0
+	for i := 0; i < len(number); i++ {
+		digit := number[len(number)-1-i] - '0'
+		if digit > 9 {
+			return false
+		}
+		checksum = verhoeffMultiplication[checksum][verhoeffPermutation[i%8][digit]]
+	}
+	return checksum == 0
+}
+
+// validateAadhaar validates an Indian Aadhaar number (12 digits, Verhoeff check digit)
+func validateAadhaar(aadhaar string) bool {
+	aadhaar = strings.NewReplacer(" ", "", "-", "").Replace(aadhaar)
+	if len(aadhaar) != 12 {
+		return false
+	}
+
+	// UIDAI never issues numbers starting with 0 or 1
+	if aadhaar[0] < '2' || aadhaar[0] > '9' {
+		return false
+	}
+
+	return validateVerhoeff(aadhaar)
+}
+
+// validatePAN validates the entity-type character of an Indian PAN
+func validatePAN(pan string) bool {
+	if len(pan) != 10 {
+		return false
+	}
+
+	// 4th character identifies the holder type:
+	// P=person, C=company, H=HUF, F=firm, A=AOP, T=trust,
+	// B=BOI, L=local authority, J=artificial juridical person, G=government
+	return strings.IndexByte("PCHFATBLJG", pan[3]) >= 0
+}
+
+// upiHandles lists the VPA suffixes issued by common UPI payment service providers
+var upiHandles = map[string]bool{
+	"upi": true, "ybl": true, "ibl": true, "axl": true, "apl": true,
+	"paytm": true, "ptyes": true, "ptaxis": true, "pthdfc": true, "ptsbi": true,
+	"okaxis": true, "okhdfcbank": true, "okicici": true, "oksbi": true,
+	"axisbank": true, "hdfcbank": true, "icici": true, "sbi": true,
+	"kotak": true, "yesbank": true, "idfcbank": true, "indus": true,
+	"federal": true, "rbl": true, "aubank": true, "freecharge": true,
+	"jio": true, "airtel": true, "waaxis": true, "wahdfcbank": true,
+	"wasbi": true, "waicici": true, "pingpay": true, "abfspay": true,
+}
+
+// validateUPI validates that a matched VPA uses a known UPI handle
+func validateUPI(vpa string) bool {
+	at := strings.LastIndexByte(vpa, '@')
+	if at <= 0 {
+		return false
+	}
+	return upiHandles[strings.ToLower(vpa[at+1:])]
+}
+
+// getIndiaPatterns returns PII patterns for India
+func getIndiaPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: India,
+		FieldNames: []string{
+			"aadhaar", "aadhaarNumber", "aadhaar_number", "aadhar", "aadharNumber",
+			"pan", "panNumber", "pan_number", "panCard", "pan_card",
+			"upi", "upiId", "upi_id", "vpa",
+			"accountNumber", "account_number", "bankAccount", "bank_account",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "india_aadhaar",
+				// Format: XXXX XXXX XXXX (12 digits, last digit is Verhoeff checksum)
+				Pattern:   regexp.MustCompile(`\b[2-9]\d{3}[\s-]?\d{4}[\s-]?\d{4}\b`),
+				Validator: validateAadhaar,
+			},
+			{
+				Name: "india_masked_aadhaar",
+				// Masked Aadhaar as printed on e-Aadhaar: XXXX XXXX 1234
+				Pattern: regexp.MustCompile(`(?i)\bX{4}[\s-]?X{4}[\s-]?\d{4}\b`),
+			},
+			{
+				Name: "india_pan",
+				// Format: AAAPA1234A (3 letters + entity type + surname initial + 4 digits + check letter)
+				Pattern:   regexp.MustCompile(`\b[A-Z]{5}\d{4}[A-Z]\b`),
+				Validator: validatePAN,
+			},
+			{
+				Name: "india_upi",
+				// VPA: name@handle where the handle has no dot (distinguishes it from an email)
+				// RE2 has no lookahead, so the delimiter after the handle is matched outside
+				// the value group
+				Pattern:   regexp.MustCompile(`\b(?P<value>[A-Za-z0-9._-]{2,256}@[A-Za-z][A-Za-z0-9]{1,63})(?:\.?(?:[^A-Za-z0-9.@_-]|$))`),
+				Validator: validateUPI,
+			},
+			{
+				Name: "india_phone",
+				// Phone: +91 / 0091 followed by 10-digit mobile number starting with 6-9
+				Pattern: regexp.MustCompile(`(?:\+91|\b0091)[\s-]?[6-9]\d{4}[\s-]?\d{5}\b`),
+			},
+			// NOTE: Bank account content pattern omitted - use field name matching only
+		},
+	}
+}
@@ -0,0 +1,111 @@
+package sanitizer
+
+import (
+	"testing"
+)
+
+func TestSanitizeField_India(t *testing.T) {
+	s := NewForRegion(India)
+
+	tests := []struct {
+		name       string
+		fieldName  string
+		value      string
+		shouldMask bool
+	}{
+		{"Aadhaar with spaces", "text", "Aadhaar: 2341 2341 2346", true},
+		{"Aadhaar without spaces", "text", "499187654323", true},
+		{"Aadhaar with dashes", "text", "8765-4321-0988", true},
+		{"Aadhaar bad checksum", "text", "2341 2341 2345", false},
+		{"Aadhaar starting with 1", "text", "1341 2341 2346", false},
+		{"Masked Aadhaar", "text", "UID XXXX XXXX 2346", true},
+		{"Masked Aadhaar lowercase", "text", "xxxx-xxxx-2346", true},
+		{"PAN individual", "text", "PAN ABCPE1234F", true},
+		{"PAN company", "text", "AAACR5055K", true},
+		{"PAN invalid entity type", "text", "ABCXE1234F", false},
+		{"UPI VPA", "text", "pay to ravi.kumar@okaxis", true},
+		{"UPI VPA end of sentence", "text", "Send to 9876543210@ybl.", true},
+		{"UPI unknown handle", "text", "git@github", false},
+		{"Phone with +91", "text", "+91 98765 43210", true},
+		{"Phone with 0091", "text", "00919876543210", true},
+		{"Phone invalid prefix", "text", "+91 12345 67890", false},
+		{"Aadhaar field name", "aadhaarNumber", "anything", true},
+		{"PAN field name", "pan_number", "anything", true},
+		{"VPA field name", "vpa", "anything", true},
+		{"Non-PII", "orderId", "ORD-12345", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.SanitizeField(tt.fieldName, tt.value)
+			if tt.shouldMask && result != "[REDACTED]" {
+				t.Errorf("Expected [REDACTED], got: %s", result)
+			}
+			if !tt.shouldMask && result != tt.value {
+				t.Errorf("Expected value to be preserved, but got: %s", result)
+			}
+		})
+	}
+}
+
+func TestIndia_UPIDistinctFromEmail(t *testing.T) {
+	s := NewForRegion(India)
+
+	if got := s.contentMatcher.matchType("ravi@okicici"); got != "india_upi" {
+		t.Errorf("Expected india_upi, got %q", got)
+	}
+
+	// Emails are detected by the common email pattern, never as UPI
+	for _, email := range []string{"ravi@okicici.com", "user@upi.example.org"} {
+		if got := s.contentMatcher.matchType(email); got != "email" {
+			t.Errorf("matchType(%q) = %q, expected email", email, got)
+		}
+	}
+}
+
+func TestIndia_UPISpans(t *testing.T) {
+	s := NewForRegion(India)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"paid (john@okaxis) ok", "paid ([REDACTED]) ok"},
+		{"pay john@okaxis, thanks", "pay [REDACTED], thanks"},
+		{"Send to 9876543210@ybl.", "Send to [REDACTED]."},
+		{"vpa john@okaxis", "vpa [REDACTED]"},
+	}
+
+	for _, tt := range tests {
+		if got := s.sanitizeText(tt.input); got != tt.expected {
+			t.Errorf("sanitizeText(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestValidateVerhoeff(t *testing.T) {
+	tests := []struct {
+		number string
+		valid  bool
+	}{
+		{"2363", true},
+		{"2364", false},
+		{"234123412346", true},
+		{"234123412347", false},
+		{"12a4", false},
+	}
+
+	for _, tt := range tests {
+		if got := validateVerhoeff(tt.number); got != tt.valid {
+			t.Errorf("validateVerhoeff(%q) = %v, expected %v", tt.number, got, tt.valid)
+		}
+	}
+}
+
+func TestIndia_NotEnabledByDefault(t *testing.T) {
+	s := NewDefault()
+
+	if result := s.SanitizeField("text", "ABCPE1234F"); result != "ABCPE1234F" {
+		t.Errorf("Expected PAN to be preserved when India region is disabled, got %s", result)
+	}
+}
@@ -0,0 +1,68 @@
+package sanitizer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// codiceFiscaleOddValues maps characters in odd positions (1-based) to their check values
+var codiceFiscaleOddValues = map[byte]int{
+	'0': 1, '1': 0, '2': 5, '3': 7, '4': 9, '5': 13, '6': 15, '7': 17, '8': 19, '9': 21,
+	'A': 1, 'B': 0, 'C': 5, 'D': 7, 'E': 9, 'F': 13, 'G': 15, 'H': 17, 'I': 19, 'J': 21,
+	'K': 2, 'L': 4, 'M': 18, 'N': 20, 'O': 11, 'P': 3, 'Q': 6, 'R': 8, 'S': 12, 'T': 14,
+	'U': 16, 'V': 10, 'W': 22, 'X': 25, 'Y': 24, 'Z': 23,
+}
+
+// validateCodiceFiscale validates the check character of an Italian Codice Fiscale
+func validateCodiceFiscale(cf string) bool {
+	cf = strings.ToUpper(cf)
+	if len(cf) != 16 {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 15; i++ {
+		c := cf[i]
+		if i%2 == 0 {
+			// Odd position (1-based)
+			value, ok := codiceFiscaleOddValues[c]
+			if !ok {
+				return false
+			}
+			sum += value
+		} else if c >= '0' && c <= '9' {
+			sum += int(c - '0')
+		} else {
+			sum += int(c - 'A')
+		}
+	}
+
+	return cf[15] == byte('A'+sum%26)
+}
+
+// getItalyPatterns returns PII patterns for Italy
+func getItalyPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: Italy,
+		FieldNames: []string{
+			"codiceFiscale", "codice_fiscale", "cf",
+			"nome", "cognome", "dataDiNascita", "data_di_nascita", "indirizzo",
+			"iban", "accountNumber", "account_number", "bankAccount", "bank_account",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "italy_codice_fiscale",
+				// Format: 6 letters (surname/name) + YY + month letter + DD + place code + check letter
+				// Digits may be replaced by letters (omocodia) when codes collide
+				Pattern:   regexp.MustCompile(`(?i)\b[A-Z]{6}[0-9LMNPQRSTUV]{2}[ABCDEHLMPRST][0-9LMNPQRSTUV]{2}[A-Z][0-9LMNPQRSTUV]{3}[A-Z]\b`),
+				Validator: validateCodiceFiscale,
+			},
+			{
+				Name: "italy_iban",
+				// IBAN: IT + 2 check digits + CIN letter + 22 chars (27 chars total)
+				Pattern:   ibanPattern("IT", 23),
+				Validator: validateIBAN,
+			},
+		},
+	}
+}
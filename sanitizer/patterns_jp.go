@@ -0,0 +1,60 @@
+package sanitizer
+
+import "regexp"
+
+// validateMyNumber validates a Japanese Individual Number (My Number) check digit
+func validateMyNumber(number string) bool {
+	digits := extractDigits(number)
+	if len(digits) != 12 {
+		return false
+	}
+
+	// Weights run from the digit left of the check digit: n+1 for n=1..6, n-5 for n=7..11
+	sum := 0
+	for n := 1; n <= 11; n++ {
+		weight := n + 1
+		if n >= 7 {
+			weight = n - 5
+		}
+		sum += digits[11-n] * weight
+	}
+
+	expected := 0
+	if remainder := sum % 11; remainder > 1 {
+		expected = 11 - remainder
+	}
+
+	return digits[11] == expected
+}
+
+// getJapanPatterns returns PII patterns for Japan
+func getJapanPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: Japan,
+		FieldNames: []string{
+			"myNumber", "my_number", "kojinBango", "kojin_bango", "individualNumber",
+			// Romanized name fields
+			"shimei", "seimei", "sei", "mei", "myoji",
+			// Kana name fields
+			"kana", "kanaName", "kana_name", "nameKana", "name_kana", "furigana",
+			"lastNameKana", "last_name_kana", "firstNameKana", "first_name_kana",
+			"seiKana", "sei_kana", "meiKana", "mei_kana",
+			"氏名", "姓", "名", "フリガナ", "ふりがな", "カナ氏名", "個人番号", "マイナンバー",
+			"accountNumber", "account_number", "bankAccount", "bank_account", "kouzaBangou",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "japan_my_number",
+				// Format: 12 digits (11 digits + check digit), often grouped 4-4-4
+				Pattern:   regexp.MustCompile(`\b\d{4}[\s-]?\d{4}[\s-]?\d{4}\b`),
+				Validator: validateMyNumber,
+			},
+			{
+				Name: "japan_phone",
+				// Mobile: +81 / 0081 + 70/80/90 + 8 digits, or domestic 070/080/090-XXXX-XXXX
+				Pattern: regexp.MustCompile(`(?:(?:\+81|\b0081)[\s-]?|\b0)[789]0[\s-]?\d{4}[\s-]?\d{4}\b`),
+			},
+			// NOTE: Bank account content pattern omitted - use field name matching only
+		},
+	}
+}
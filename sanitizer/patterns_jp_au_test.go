@@ -0,0 +1,104 @@
+package sanitizer
+
+import (
+	"testing"
+)
+
+func TestSanitizeField_Japan(t *testing.T) {
+	s := NewForRegion(Japan)
+
+	tests := []struct {
+		name       string
+		fieldName  string
+		value      string
+		shouldMask bool
+	}{
+		{"My Number", "text", "123456789018", true},
+		{"My Number grouped", "text", "My Number: 9876 5432 1093", true},
+		{"My Number bad check digit", "text", "123456789019", false},
+		{"Mobile with +81", "text", "+81 90 1234 5678", true},
+		{"Mobile domestic", "text", "080-1234-5678", true},
+		{"Landline-like number", "text", "03-1234-5678", false},
+		{"Romanized name field", "seimei", "Yamada Taro", true},
+		{"Kana name field", "nameKana", "ヤマダ タロウ", true},
+		{"Japanese name field", "氏名", "山田太郎", true},
+		{"Furigana field", "furigana", "やまだ たろう", true},
+		{"My Number field name", "my_number", "anything", true},
+		{"Non-PII", "orderId", "ORD-12345", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.SanitizeField(tt.fieldName, tt.value)
+			if tt.shouldMask && result != "[REDACTED]" {
+				t.Errorf("Expected [REDACTED], got: %s", result)
+			}
+			if !tt.shouldMask && result != tt.value {
+				t.Errorf("Expected value to be preserved, but got: %s", result)
+			}
+		})
+	}
+}
+
+func TestSanitizeField_Australia(t *testing.T) {
+	s := NewForRegion(Australia)
+
+	tests := []struct {
+		name       string
+		fieldName  string
+		value      string
+		shouldMask bool
+	}{
+		{"TFN", "text", "TFN 123 456 782", true},
+		{"TFN bad checksum", "text", "123 456 789", false},
+		{"Medicare", "text", "2123 45670 1", true},
+		{"Medicare without IRN", "text", "2123456701", true},
+		{"Medicare bad check digit", "text", "2123456711", false},
+		{"Medicare invalid first digit", "text", "7123456701", false},
+		{"ABN with keyword", "text", "ABN: 51 824 753 556", true},
+		{"ABN bad checksum", "text", "ABN 51 824 753 557", false},
+		{"ABN without keyword", "text", "51 824 753 556", false},
+		{"Mobile with +61", "text", "+61 412 345 678", true},
+		{"Mobile domestic", "text", "0412 345 678", true},
+		{"TFN field name", "taxFileNumber", "anything", true},
+		{"Medicare field name", "medicare_number", "anything", true},
+		{"Non-PII", "orderId", "ORD-12345", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.SanitizeField(tt.fieldName, tt.value)
+			if tt.shouldMask && result != "[REDACTED]" {
+				t.Errorf("Expected [REDACTED], got: %s", result)
+			}
+			if !tt.shouldMask && result != tt.value {
+				t.Errorf("Expected value to be preserved, but got: %s", result)
+			}
+		})
+	}
+}
+
+func TestJapanAustralia_Validators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator func(string) bool
+		value     string
+		valid     bool
+	}{
+		{"My Number valid", validateMyNumber, "473629581044", true},
+		{"My Number too short", validateMyNumber, "47362958104", false},
+		{"TFN valid", validateTFN, "123456782", true},
+		{"TFN too short", validateTFN, "12345678", false},
+		{"Medicare with IRN", validateMedicare, "2123 45670 1-2", true},
+		{"ABN valid", validateABN, "51824753556", true},
+		{"ABN too long", validateABN, "518247535561", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.validator(tt.value); got != tt.valid {
+				t.Errorf("validator(%q) = %v, expected %v", tt.value, got, tt.valid)
+			}
+		})
+	}
+}
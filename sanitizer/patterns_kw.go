@@ -0,0 +1,61 @@
+package sanitizer
+
+import "regexp"
+
+// validateKuwaitCivilID validates a Kuwait civil ID checksum.
+// Format: C YYMMDD NNNN K, where C is the century (2=1900s, 3=2000s).
+func validateKuwaitCivilID(id string) bool {
+	digits := extractDigits(id)
+	if len(digits) != 12 {
+		return false
+	}
+
+	if digits[0] != 2 && digits[0] != 3 {
+		return false
+	}
+
+	month := digits[3]*10 + digits[4]
+	day := digits[5]*10 + digits[6]
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return false
+	}
+
+	weights := []int{2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	sum := 0
+	for i, weight := range weights {
+		sum += digits[i] * weight
+	}
+
+	return 11-sum%11 == digits[11]
+}
+
+// getKuwaitPatterns returns PII patterns for Kuwait
+func getKuwaitPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: Kuwait,
+		FieldNames: []string{
+			"civilId", "civil_id", "civilNumber", "civil_number", "kuwaitId",
+			"nationalId", "national_id", "identityCard", "identity_card",
+			"iban", "accountNumber", "account_number", "bankAccount", "bank_account",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "kuwait_civil_id",
+				// Format: 12 digits (century + YYMMDD + serial + check digit)
+				Pattern:   regexp.MustCompile(`\b[23]\d{11}\b`),
+				Validator: validateKuwaitCivilID,
+			},
+			{
+				Name: "kuwait_phone",
+				// Phone: +965 / 00965 + 8 digits (mobile: 5/6/9 prefix)
+				Pattern: regexp.MustCompile(`(?:\+965|\b00965)[\s-]?[569]\d{3}[\s-]?\d{4}\b`),
+			},
+			{
+				Name: "kuwait_iban",
+				// IBAN: KW + 2 check digits + 4-letter bank code + 22 chars (30 chars total)
+				Pattern:   ibanPattern("KW", 26),
+				Validator: validateIBAN,
+			},
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package sanitizer
+
+import "regexp"
+
+// validateBSN validates a Dutch citizen service number (BSN) using the 11-proof
+func validateBSN(bsn string) bool {
+	digits := extractDigits(bsn)
+	if len(digits) != 9 {
+		return false
+	}
+
+	// Weights 9..2 for the first 8 digits, -1 for the last
+	sum := 0
+	for i := 0; i < 8; i++ {
+		sum += digits[i] * (9 - i)
+	}
+	sum -= digits[8]
+
+	return sum != 0 && sum%11 == 0
+}
+
+// getNetherlandsPatterns returns PII patterns for the Netherlands
+func getNetherlandsPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: Netherlands,
+		FieldNames: []string{
+			"bsn", "burgerservicenummer", "sofinummer", "sofiNummer",
+			"voornaam", "achternaam", "geboortedatum", "adres",
+			"iban", "accountNumber", "account_number", "bankAccount", "bank_account", "rekeningnummer",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "netherlands_bsn",
+				// Format: 9 digits, sometimes written 1234.56.782
+				Pattern:   regexp.MustCompile(`\b\d{4}\.?\d{2}\.?\d{3}\b`),
+				Validator: validateBSN,
+			},
+			{
+				Name: "netherlands_iban",
+				// IBAN: NL + 2 check digits + 4-letter bank code + 10 digits (18 chars total)
+				Pattern:   ibanPattern("NL", 14),
+				Validator: validateIBAN,
+			},
+		},
+	}
+}
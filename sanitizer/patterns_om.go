@@ -0,0 +1,34 @@
+package sanitizer
+
+import "regexp"
+
+// getOmanPatterns returns PII patterns for Oman
+func getOmanPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: Oman,
+		FieldNames: []string{
+			"civilId", "civil_id", "civilNumber", "civil_number", "omanId", "oman_id",
+			"nationalId", "national_id", "identityCard", "identity_card",
+			"iban", "accountNumber", "account_number", "bankAccount", "bank_account",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "oman_civil_number",
+				// Format: up to 9 digits, no checksum
+				// Only matched next to a civil number keyword to avoid matching arbitrary numbers
+				Pattern: regexp.MustCompile(`(?i)\bcivil\s?(?:ID|No\.?|Number)[\s:#-]*\d{6,9}\b`),
+			},
+			{
+				Name: "oman_phone",
+				// Phone: +968 / 00968 + 8 digits (mobile: 7/9 prefix)
+				Pattern: regexp.MustCompile(`(?:\+968|\b00968)[\s-]?[79]\d{3}[\s-]?\d{4}\b`),
+			},
+			{
+				Name: "oman_iban",
+				// IBAN: OM + 2 check digits + 3-digit bank code + 16 chars (23 chars total)
+				Pattern:   ibanPattern("OM", 19),
+				Validator: validateIBAN,
+			},
+		},
+	}
+}
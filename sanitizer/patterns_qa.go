@@ -0,0 +1,34 @@
+package sanitizer
+
+import "regexp"
+
+// getQatarPatterns returns PII patterns for Qatar
+func getQatarPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: Qatar,
+		FieldNames: []string{
+			"qid", "qatarId", "qatar_id", "qidNumber", "qid_number",
+			"nationalId", "national_id", "identityCard", "identity_card",
+			"iban", "accountNumber", "account_number", "bankAccount", "bank_account",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "qatar_qid",
+				// Format: 11 digits (century digit 2/3 + YY + 3-digit nationality code + serial)
+				// QID has no public checksum, so bare numbers are only matched next to a QID keyword
+				Pattern: regexp.MustCompile(`(?i)\b(?:QID|Qatar\s?ID)\s?(?:No\.?|Number)?[\s:#-]*[23]\d{10}\b`),
+			},
+			{
+				Name: "qatar_phone",
+				// Phone: +974 / 00974 + 8 digits (mobile: 3/5/6/7 prefix)
+				Pattern: regexp.MustCompile(`(?:\+974|\b00974)[\s-]?[3567]\d{3}[\s-]?\d{4}\b`),
+			},
+			{
+				Name: "qatar_iban",
+				// IBAN: QA + 2 check digits + 4-letter bank code + 21 chars (29 chars total)
+				Pattern:   ibanPattern("QA", 25),
+				Validator: validateIBAN,
+			},
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package sanitizer
+
+import "regexp"
+
+// validateSaudiID validates a Saudi national ID or Iqama number.
+// 10 digits, first digit 1 (citizen) or 2 (resident), Luhn check digit.
+func validateSaudiID(id string) bool {
+	digits := extractDigits(id)
+	if len(digits) != 10 {
+		return false
+	}
+
+	if digits[0] != 1 && digits[0] != 2 {
+		return false
+	}
+
+	return luhnValid(digits)
+}
+
+// getSaudiArabiaPatterns returns PII patterns for Saudi Arabia
+func getSaudiArabiaPatterns() RegionalPatterns {
+	return RegionalPatterns{
+		Region: SaudiArabia,
+		FieldNames: []string{
+			"saudiId", "saudi_id", "iqama", "iqamaNumber", "iqama_number",
+			"nationalId", "national_id", "identityCard", "identity_card",
+			"iban", "accountNumber", "account_number", "bankAccount", "bank_account",
+		},
+		ContentPatterns: []ContentPattern{
+			{
+				Name: "saudi_national_id",
+				// Format: 1XXXXXXXXX (citizen) or 2XXXXXXXXX (Iqama), Luhn check digit
+				Pattern:   regexp.MustCompile(`\b[12]\d{9}\b`),
+				Validator: validateSaudiID,
+			},
+			{
+				Name: "saudi_phone",
+				// Phone: +966 / 00966 / 0 + mobile prefix 5 + 8 digits
+				Pattern: regexp.MustCompile(`(?:\+966|\b00966|\b0)5\d{8}\b`),
+			},
+			{
+				Name: "saudi_iban",
+				// IBAN: SA + 2 check digits + 20 chars (24 chars total)
+				Pattern:   ibanPattern("SA", 20),
+				Validator: validateIBAN,
+			},
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package sanitizer
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// PanicError is a recovered panic with PII redacted from its value and stack trace
+type PanicError struct {
+	// Value is the sanitized panic value: an error wrapped with Sanitizer.Error
+	// (so errors.Is and errors.As still work), or a sanitized string
+	Value any
+
+	// Stack is the stack trace of the panicking goroutine, sanitized as free text
+	Stack []byte
+}
+
+// Error implements error
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it was an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Recover recovers a panic in the calling goroutine and passes it to handle with PII
+// redacted. If handle is nil the panic is logged with slog.Default. It must be deferred
+// directly, as recover only works in the deferred function itself:
+//
+//	defer s.Recover(func(p *PanicError) {
+//		logger.Error("panic", "error", p, "stack", string(p.Stack))
+//	})
+func (s *Sanitizer) Recover(handle func(*PanicError)) {
+	if v := recover(); v != nil {
+		if handle == nil {
+			handle = logPanic
+		}
+		handle(s.panicError(v))
+	}
+}
+
+// Go runs fn in a new goroutine, reporting a panic to handle via Recover instead of
+// crashing the process
+func (s *Sanitizer) Go(fn func(), handle func(*PanicError)) {
+	go func() {
+		defer s.Recover(handle)
+		fn()
+	}()
+}
+
+// RecoverHandler returns HTTP middleware that recovers panics in next, reports them to
+// handle with PII redacted and responds with 500 Internal Server Error, unless next has
+// already started the response. If handle is nil the panic is logged with
+// slog.Default. http.ErrAbortHandler is re-panicked so that net/http aborts the
+// response as usual.
+//
+// Example:
+//
+//	s := NewDefault()
+//	http.ListenAndServe(":8080", RecoverHandler(mux, s, nil))
+func RecoverHandler(next http.Handler, s *Sanitizer, handle func(*http.Request, *PanicError)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Body capture is disabled; only the status is tracked
+		rw := &responseRecorder{ResponseWriter: w}
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+
+			p := s.panicError(v)
+			if handle != nil {
+				handle(r, p)
+			} else {
+				slog.Error("panic recovered",
+					"method", r.Method,
+					"path", s.sanitizeText(r.URL.Path),
+					"panic", p.Value,
+					"stack", string(p.Stack),
+				)
+			}
+			if rw.status == 0 {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// panicError sanitizes a recovered panic value and the current stack trace
+func (s *Sanitizer) panicError(v any) *PanicError {
+	var value any
+	switch val := v.(type) {
+	case error:
+		value = s.Error(val)
+	case string:
+		value = s.sanitizeText(val)
+	case fmt.Stringer:
+		value = s.sanitizeText(safeStringer(val))
+	default:
+		value = s.sanitizeText(fmt.Sprint(val))
+	}
+
+	// Stack frames can carry string arguments and panic messages from nested panics
+	return &PanicError{Value: value, Stack: []byte(s.sanitizeText(string(debug.Stack())))}
+}
+
+// logPanic is the default panic handler
+func logPanic(p *PanicError) {
+	slog.Error("panic recovered", "panic", p.Value, "stack", string(p.Stack))
+}
@@ -235,7 +235,7 @@ func TestSanitizeMap_NilMap(t *testing.T) {
 func TestSanitizeSlice_NilSlice(t *testing.T) {
 	s := NewDefault()
 
-	result := s.sanitizeSlice(nil, 0)
+	result := s.sanitizeSlice(nil, "", 0)
 
 	// sanitizeSlice returns empty slice for nil input, not nil
 	if len(result) != 0 {
@@ -246,7 +246,7 @@ func TestSanitizeSlice_NilSlice(t *testing.T) {
 func TestSanitizeSlice_EmptySlice(t *testing.T) {
 	s := NewDefault()
 
-	result := s.sanitizeSlice([]any{}, 0)
+	result := s.sanitizeSlice([]any{}, "", 0)
 
 	if len(result) != 0 {
 		t.Error("Expected empty slice result")
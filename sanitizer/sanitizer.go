@@ -16,6 +16,7 @@ type Sanitizer struct {
 	contentMatcher *contentMatcher
 	explicitRedact map[string]bool // Quick lookup for AlwaysRedact
 	explicitSafe   map[string]bool // Quick lookup for NeverRedact
+	safeTypes      map[string]bool // Quick lookup for NeverRedactTypes
 }
 
 // New creates a new Sanitizer with the given configuration.
@@ -46,6 +47,7 @@ func New(config *Config) *Sanitizer {
 		config:         config,
 		explicitRedact: make(map[string]bool),
 		explicitSafe:   make(map[string]bool),
+		safeTypes:      make(map[string]bool),
 	}
 
 	// Build explicit redact/safe maps for quick lookup
@@ -55,6 +57,9 @@ func New(config *Config) *Sanitizer {
 	for _, field := range config.NeverRedact {
 		s.explicitSafe[strings.ToLower(field)] = true
 	}
+	for _, piiType := range config.NeverRedactTypes {
+		s.safeTypes[strings.ToLower(piiType)] = true
+	}
 
 	// Compile patterns
 	s.compilePatterns()
@@ -189,21 +194,42 @@ func (s *Sanitizer) SanitizeField(fieldName, value string) string {
 	return value
 }
 
-// SanitizeMap sanitizes a map (common for JSON-like structures)
+// SanitizeMap sanitizes a map (common for JSON-like structures).
+// If the config has a Schema, its path rules take precedence over field name and
+// content rules.
 func (s *Sanitizer) SanitizeMap(m map[string]any) map[string]any {
-	return s.sanitizeMapRecursive(m, 0)
+	return s.sanitizeMapRecursive(m, "", 0)
 }
 
-// sanitizeMapRecursive sanitizes a map recursively with depth tracking
-func (s *Sanitizer) sanitizeMapRecursive(m map[string]any, depth int) map[string]any {
+// sanitizeMapRecursive sanitizes a map recursively with depth tracking. path is the
+// map's schema path, used only if a Schema is configured.
+func (s *Sanitizer) sanitizeMapRecursive(m map[string]any, path string, depth int) map[string]any {
 	if depth > s.config.MaxDepth {
 		return m
 	}
 
+	schema := s.config.Schema
 	result := make(map[string]any)
 	for k, v := range m {
+		var childPath string
+		var rule schemaRule
+		if schema != nil {
+			childPath, rule = schema.child(path, k)
+			if s.schemaRedacts(rule) {
+				if redacted, ok := s.applySchemaRule(v); ok {
+					result[k] = redacted
+				}
+				continue
+			}
+		}
+
 		switch val := v.(type) {
 		case string:
+			// Preserved by the schema
+			if rule.action != schemaNone {
+				result[k] = val
+				continue
+			}
 			sanitized := s.SanitizeField(k, val)
 			// If strategy is Remove and value was redacted, skip this field
 			if s.config.Strategy == StrategyRemove && sanitized == "" && val != "" {
@@ -212,10 +238,10 @@ func (s *Sanitizer) sanitizeMapRecursive(m map[string]any, depth int) map[string
 			result[k] = sanitized
 
 		case map[string]any:
-			result[k] = s.sanitizeMapRecursive(val, depth+1)
+			result[k] = s.sanitizeMapRecursive(val, childPath, depth+1)
 
 		case []any:
-			result[k] = s.sanitizeSlice(val, depth+1)
+			result[k] = s.sanitizeSlice(val, childPath, depth+1)
 
 		default:
 			// For non-string types, preserve as-is
@@ -225,28 +251,41 @@ func (s *Sanitizer) sanitizeMapRecursive(m map[string]any, depth int) map[string
 	return result
 }
 
-// sanitizeSlice sanitizes a slice recursively
-func (s *Sanitizer) sanitizeSlice(slice []any, depth int) []any {
+// sanitizeSlice sanitizes a slice recursively. path is the slice's schema path, used
+// only if a Schema is configured.
+func (s *Sanitizer) sanitizeSlice(slice []any, path string, depth int) []any {
 	if depth > s.config.MaxDepth {
 		return slice
 	}
 
+	var itemPath string
+	var rule schemaRule
+	if s.config.Schema != nil {
+		itemPath, rule = s.config.Schema.items(path)
+	}
+
 	result := make([]any, len(slice))
 	for i, v := range slice {
+		// Removed items keep their position as an empty value
+		if s.schemaRedacts(rule) {
+			result[i], _ = s.applySchemaRule(v)
+			continue
+		}
+
 		switch val := v.(type) {
 		case string:
-			// For slices, we don't have field names, so only check content
-			if s.contentMatcher.matches(val) {
-				result[i] = s.redact(val)
-			} else {
+			if rule.action != schemaNone {
 				result[i] = val
+				continue
 			}
+			// For slices, we don't have field names, so only check content
+			result[i] = s.sanitizeContent(val)
 
 		case map[string]any:
-			result[i] = s.sanitizeMapRecursive(val, depth+1)
+			result[i] = s.sanitizeMapRecursive(val, itemPath, depth+1)
 
 		case []any:
-			result[i] = s.sanitizeSlice(val, depth+1)
+			result[i] = s.sanitizeSlice(val, itemPath, depth+1)
 
 		default:
 			result[i] = val
@@ -255,6 +294,52 @@ func (s *Sanitizer) sanitizeSlice(slice []any, depth int) []any {
 	return result
 }
 
+// sanitizeContent sanitizes a value that has no field name, using content patterns only
+func (s *Sanitizer) sanitizeContent(value string) string {
+	if s.contentMatcher.matches(value) {
+		return s.redact(value)
+	}
+	return value
+}
+
+// sanitizeText sanitizes free text by redacting only the PII spans inside it,
+// leaving the surrounding text intact (e.g. "sent to [REDACTED] at 10:00")
+func (s *Sanitizer) sanitizeText(text string) string {
+	spans := s.contentMatcher.findSpans(text)
+	if len(spans) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	last := 0
+	for _, span := range spans {
+		b.WriteString(text[last:span[0]])
+		b.WriteString(s.redact(text[span[0]:span[1]]))
+		last = span[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// sanitizeFieldText sanitizes a free text value such as an error message. Field name
+// rules apply as in SanitizeField, but content matches only redact the PII spans.
+func (s *Sanitizer) sanitizeFieldText(fieldName, value string) string {
+	if value == "" {
+		return value
+	}
+
+	fieldNameLower := strings.ToLower(fieldName)
+	if s.explicitSafe[fieldNameLower] {
+		return value
+	}
+	if s.explicitRedact[fieldNameLower] || s.fieldMatcher.matches(fieldName) {
+		return s.redact(value)
+	}
+
+	return s.sanitizeText(value)
+}
+
 // SanitizeJSON sanitizes JSON data
 func (s *Sanitizer) SanitizeJSON(data []byte) ([]byte, error) {
 	var m map[string]any
@@ -0,0 +1,339 @@
+package sanitizer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Schema holds path-based redaction rules compiled from a JSON Schema. Attach it to
+// a Config with WithSchema so that SanitizeMap, SanitizeJSON and the JSON paths of the
+// io.Writer, ZerologWriter and HTTP body logging apply the rules to payloads that
+// follow the schema.
+//
+// Rules come from two annotations on property schemas:
+//   - x-pii: "redact" always redacts the value, "preserve" never redacts it, and any
+//     other value names its PII type (e.g. "email", "phone", "nric"), which redacts it
+//     unless that type is preserved with WithPreserveTypes
+//   - format: "email" is treated as x-pii: "email" when x-pii is absent
+//
+// A redacted object, array, number or boolean is replaced as a whole. Preserving an
+// object or array keeps its structure, and its members follow their own rules.
+// Properties without annotations fall back to the usual field name and content rules.
+type Schema struct {
+	// rules maps each path to its rule; paths are property names joined with dots,
+	// with "[]" for array items and "*" for additionalProperties
+	// (e.g. "customer.email", "orders[].cardNumber", "metadata.*")
+	rules map[string]schemaRule
+
+	// known lists every path the schema describes, annotated or not
+	known map[string]bool
+}
+
+// schemaAction is the kind of a compiled schema rule, ordered from least to most
+// restrictive
+type schemaAction int
+
+const (
+	schemaNone schemaAction = iota
+	schemaPreserve
+	schemaType
+	schemaRedact
+)
+
+// schemaRule is the rule compiled for a path
+type schemaRule struct {
+	action  schemaAction
+	piiType string // for schemaType
+}
+
+// errSchemaNotObject is returned when a schema document is not a JSON object
+var errSchemaNotObject = errors.New("sanitizer: schema is not a JSON object")
+
+// LoadJSONSchema compiles the x-pii and format annotations of a JSON Schema document.
+// Local references ("#/definitions/...", "#/$defs/...") are resolved, and allOf, anyOf
+// and oneOf subschemas are merged. When several subschemas annotate the same path the
+// most restrictive rule wins: redact, then a PII type, then preserve. A recursive
+// reference is not followed again, so deeper levels use the usual rules.
+//
+// Example:
+//
+//	schema, err := sanitizer.LoadJSONSchema([]byte(`{
+//	  "type": "object",
+//	  "properties": {
+//	    "contact":  {"type": "string", "format": "email"},
+//	    "ref":      {"type": "string", "x-pii": "redact"},
+//	    "country":  {"type": "string", "x-pii": "preserve"}
+//	  }
+//	}`))
+//	s := sanitizer.New(sanitizer.NewDefaultConfig().WithSchema(schema))
+func LoadJSONSchema(data []byte) (*Schema, error) {
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, errSchemaNotObject
+	}
+	return compileSchema(root, root)
+}
+
+// LoadOpenAPISchema compiles the named schema of an OpenAPI JSON document, from
+// components.schemas (OpenAPI 3) or definitions (Swagger 2), like LoadJSONSchema.
+// References to other schemas of the document are resolved.
+//
+// Example:
+//
+//	schema, err := sanitizer.LoadOpenAPISchema(openapiJSON, "CreateCustomerRequest")
+func LoadOpenAPISchema(data []byte, name string) (*Schema, error) {
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, errSchemaNotObject
+	}
+
+	for _, pointer := range []string{"#/components/schemas/", "#/definitions/"} {
+		if node, ok := resolveSchemaPointer(root, pointer+escapeSchemaPointer(name)); ok {
+			return compileSchema(root, node)
+		}
+	}
+	return nil, fmt.Errorf("sanitizer: schema %q not found", name)
+}
+
+// WithSchema sets path-based rules compiled from a JSON Schema or OpenAPI document
+func (c *Config) WithSchema(schema *Schema) *Config {
+	c.Schema = schema
+	return c
+}
+
+// Rules returns the annotated paths and their rules: "redact", "preserve" or the PII
+// type
+func (sc *Schema) Rules() map[string]string {
+	rules := make(map[string]string, len(sc.rules))
+	for path, rule := range sc.rules {
+		switch rule.action {
+		case schemaRedact:
+			rules[path] = "redact"
+		case schemaPreserve:
+			rules[path] = "preserve"
+		case schemaType:
+			rules[path] = rule.piiType
+		}
+	}
+	return rules
+}
+
+// child returns the path of key under parent and its rule. Keys the schema does not
+// list fall back to the parent's additionalProperties ("parent.*").
+func (sc *Schema) child(parent, key string) (string, schemaRule) {
+	path := joinSchemaPath(parent, key)
+	if !sc.known[path] {
+		if wildcard := joinSchemaPath(parent, "*"); sc.known[wildcard] {
+			path = wildcard
+		}
+	}
+	return path, sc.rules[path]
+}
+
+// items returns the path of the items of the array at parent and their rule
+func (sc *Schema) items(parent string) (string, schemaRule) {
+	path := parent + "[]"
+	return path, sc.rules[path]
+}
+
+// compileSchema compiles the schema node, resolving references against root
+func compileSchema(root, node map[string]any) (*Schema, error) {
+	c := &schemaCompiler{
+		root:   root,
+		schema: &Schema{rules: make(map[string]schemaRule), known: make(map[string]bool)},
+		refs:   make(map[string]bool),
+	}
+	if err := c.compile(node, ""); err != nil {
+		return nil, err
+	}
+	return c.schema, nil
+}
+
+// schemaCompiler walks a schema, recording the rule of each path
+type schemaCompiler struct {
+	root   map[string]any
+	schema *Schema
+
+	// refs holds the references being followed, to stop at recursive schemas
+	refs map[string]bool
+}
+
+// compile records the rules of node at path and its subschemas
+func (c *schemaCompiler) compile(node map[string]any, path string) error {
+	c.schema.known[path] = true
+
+	rule, err := schemaAnnotation(node, path)
+	if err != nil {
+		return err
+	}
+	if rule.action > c.schema.rules[path].action {
+		c.schema.rules[path] = rule
+	}
+
+	if ref, ok := node["$ref"].(string); ok {
+		if err := c.compileRef(ref, path); err != nil {
+			return err
+		}
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		subschemas, _ := node[keyword].([]any)
+		for _, sub := range subschemas {
+			if err := c.compileNode(sub, path); err != nil {
+				return err
+			}
+		}
+	}
+
+	if properties, ok := node["properties"].(map[string]any); ok {
+		for name, property := range properties {
+			if err := c.compileNode(property, joinSchemaPath(path, name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Tuple items (an array of schemas) all share the items path
+	switch items := node["items"].(type) {
+	case map[string]any:
+		if err := c.compile(items, path+"[]"); err != nil {
+			return err
+		}
+	case []any:
+		for _, item := range items {
+			if err := c.compileNode(item, path+"[]"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if additional, ok := node["additionalProperties"].(map[string]any); ok {
+		if err := c.compile(additional, joinSchemaPath(path, "*")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compileNode compiles a subschema, ignoring boolean schemas
+func (c *schemaCompiler) compileNode(node any, path string) error {
+	if m, ok := node.(map[string]any); ok {
+		return c.compile(m, path)
+	}
+	return nil
+}
+
+// compileRef compiles the schema referenced by ref at path
+func (c *schemaCompiler) compileRef(ref, path string) error {
+	if c.refs[ref] {
+		return nil
+	}
+	target, ok := resolveSchemaPointer(c.root, ref)
+	if !ok {
+		return fmt.Errorf("sanitizer: unresolved schema reference %q", ref)
+	}
+
+	c.refs[ref] = true
+	defer delete(c.refs, ref)
+	return c.compile(target, path)
+}
+
+// schemaAnnotation returns the rule declared on a schema node by x-pii or format
+func schemaAnnotation(node map[string]any, path string) (schemaRule, error) {
+	value, ok := node["x-pii"]
+	if !ok {
+		if format, _ := node["format"].(string); strings.EqualFold(format, "email") {
+			return schemaRule{action: schemaType, piiType: "email"}, nil
+		}
+		return schemaRule{}, nil
+	}
+
+	annotation, _ := value.(string)
+	switch strings.ToLower(strings.TrimSpace(annotation)) {
+	case "":
+		return schemaRule{}, fmt.Errorf("sanitizer: x-pii at %q must be redact, preserve or a PII type", path)
+	case "redact":
+		return schemaRule{action: schemaRedact}, nil
+	case "preserve":
+		return schemaRule{action: schemaPreserve}, nil
+	default:
+		return schemaRule{action: schemaType, piiType: strings.TrimSpace(annotation)}, nil
+	}
+}
+
+// resolveSchemaPointer resolves a local JSON pointer reference ("#/a/b") in root
+func resolveSchemaPointer(root map[string]any, ref string) (map[string]any, bool) {
+	if ref == "#" {
+		return root, true
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, false
+	}
+
+	var node any = root
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if node, ok = m[token]; !ok {
+			return nil, false
+		}
+	}
+	m, ok := node.(map[string]any)
+	return m, ok
+}
+
+// escapeSchemaPointer escapes a name for use as a JSON pointer token
+func escapeSchemaPointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
+
+// joinSchemaPath appends a property name to a schema path
+func joinSchemaPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// applySchemaRule redacts a value whose schema rule redacts it. Strings are redacted
+// with the configured strategy and other values are replaced as a whole. It returns
+// false if the value is removed.
+func (s *Sanitizer) applySchemaRule(v any) (any, bool) {
+	switch val := v.(type) {
+	case nil:
+		return nil, true
+	case string:
+		if val == "" {
+			return val, true
+		}
+		redacted := s.redact(val)
+		return redacted, !s.isRemoved(val, redacted)
+	}
+	if s.config.Strategy == StrategyRemove {
+		return nil, false
+	}
+	return "[REDACTED]", true
+}
+
+// schemaRedacts reports whether a rule redacts values. A PII type listed in
+// NeverRedactTypes is preserved.
+func (s *Sanitizer) schemaRedacts(rule schemaRule) bool {
+	switch rule.action {
+	case schemaRedact:
+		return true
+	case schemaType:
+		return !s.safeTypes[strings.ToLower(rule.piiType)]
+	}
+	return false
+}
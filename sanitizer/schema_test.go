@@ -0,0 +1,316 @@
+package sanitizer
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testCustomerSchema annotates a customer payload. It uses field names that the
+// default rules would treat differently, so the tests show the schema taking over.
+const testCustomerSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "login":    {"type": "string", "format": "email"},
+    "ref":      {"type": "string", "x-pii": "redact"},
+    "country":  {"type": "string", "x-pii": "preserve"},
+    "balance":  {"type": "number", "x-pii": "redact"},
+    "status":   {"type": "string"},
+    "owner":    {"$ref": "#/$defs/Person"},
+    "tags":     {"type": "array", "items": {"type": "string", "x-pii": "redact"}},
+    "attributes": {"type": "object", "additionalProperties": {"type": "string", "x-pii": "handle"}},
+    "history":  {"type": "array", "items": {"$ref": "#/$defs/Event"}}
+  },
+  "$defs": {
+    "Person": {
+      "type": "object",
+      "properties": {
+        "handle": {"type": "string", "x-pii": "name"},
+        "manager": {"$ref": "#/$defs/Person"}
+      }
+    },
+    "Event": {
+      "allOf": [
+        {"properties": {"memo": {"type": "string", "x-pii": "preserve"}}},
+        {"properties": {"memo": {"type": "string", "x-pii": "redact"}, "code": {"type": "string"}}}
+      ]
+    }
+  }
+}`
+
+func TestLoadJSONSchema(t *testing.T) {
+	schema, err := LoadJSONSchema([]byte(testCustomerSchema))
+	if err != nil {
+		t.Fatalf("LoadJSONSchema() error = %v", err)
+	}
+
+	want := map[string]string{
+		"login":          "email",
+		"ref":            "redact",
+		"country":        "preserve",
+		"balance":        "redact",
+		"owner.handle":   "name",
+		"tags[]":         "redact",
+		"attributes.*":   "handle",
+		"history[].memo": "redact",
+	}
+	if got := schema.Rules(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Rules() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadJSONSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{"invalid json", `{"type":`, "unexpected end of JSON input"},
+		{"not an object", `null`, "schema is not a JSON object"},
+		{"bad annotation", `{"properties": {"id": {"x-pii": true}}}`, `x-pii at "id"`},
+		{"unresolved reference", `{"properties": {"id": {"$ref": "#/$defs/Missing"}}}`, `unresolved schema reference "#/$defs/Missing"`},
+		{"remote reference", `{"properties": {"id": {"$ref": "common.json#/Id"}}}`, "unresolved schema reference"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadJSONSchema([]byte(tt.schema))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadJSONSchema() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSanitizeMapWithSchema(t *testing.T) {
+	schema, err := LoadJSONSchema([]byte(testCustomerSchema))
+	if err != nil {
+		t.Fatalf("LoadJSONSchema() error = %v", err)
+	}
+
+	input := map[string]any{
+		"login":   "john@acme.com",
+		"ref":     "CUST-1001",
+		"country": "SG",
+		"balance": 1250.5,
+		"status":  "active",
+		"email":   "jane@acme.com",
+		"owner": map[string]any{
+			"handle":  "jdoe",
+			"manager": map[string]any{"handle": "asmith"},
+		},
+		"tags":       []any{"vip", "", "priority"},
+		"attributes": map[string]any{"nickname": "Johnny", "tier": "gold"},
+		"history": []any{
+			map[string]any{"memo": "called back", "code": "C1"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		config *Config
+		want   map[string]any
+	}{
+		{
+			name:   "full",
+			config: NewDefaultConfig(),
+			want: map[string]any{
+				"login":   "[REDACTED]",
+				"ref":     "[REDACTED]",
+				"country": "SG",
+				"balance": "[REDACTED]",
+				"status":  "active",
+				"email":   "[REDACTED]",
+				"owner": map[string]any{
+					"handle":  "[REDACTED]",
+					"manager": map[string]any{"handle": "asmith"},
+				},
+				"tags":       []any{"[REDACTED]", "", "[REDACTED]"},
+				"attributes": map[string]any{"nickname": "[REDACTED]", "tier": "[REDACTED]"},
+				"history": []any{
+					map[string]any{"memo": "[REDACTED]", "code": "C1"},
+				},
+			},
+		},
+		{
+			name:   "preserved type",
+			config: NewDefaultConfig().WithPreserveTypes("email", "handle"),
+			want: map[string]any{
+				"login":   "john@acme.com",
+				"ref":     "[REDACTED]",
+				"country": "SG",
+				"balance": "[REDACTED]",
+				"status":  "active",
+				"email":   "[REDACTED]",
+				"owner": map[string]any{
+					"handle":  "[REDACTED]",
+					"manager": map[string]any{"handle": "asmith"},
+				},
+				"tags":       []any{"[REDACTED]", "", "[REDACTED]"},
+				"attributes": map[string]any{"nickname": "Johnny", "tier": "gold"},
+				"history": []any{
+					map[string]any{"memo": "[REDACTED]", "code": "C1"},
+				},
+			},
+		},
+		{
+			name:   "preserved field name",
+			config: NewDefaultConfig().WithPreserve("email"),
+			want: map[string]any{
+				"login":   "[REDACTED]",
+				"ref":     "[REDACTED]",
+				"country": "SG",
+				"balance": "[REDACTED]",
+				"status":  "active",
+				"email":   "jane@acme.com",
+				"owner": map[string]any{
+					"handle":  "[REDACTED]",
+					"manager": map[string]any{"handle": "asmith"},
+				},
+				"tags":       []any{"[REDACTED]", "", "[REDACTED]"},
+				"attributes": map[string]any{"nickname": "[REDACTED]", "tier": "[REDACTED]"},
+				"history": []any{
+					map[string]any{"memo": "[REDACTED]", "code": "C1"},
+				},
+			},
+		},
+		{
+			name:   "remove",
+			config: NewDefaultConfig().WithStrategy(StrategyRemove),
+			want: map[string]any{
+				"country": "SG",
+				"status":  "active",
+				"owner": map[string]any{
+					"manager": map[string]any{"handle": "asmith"},
+				},
+				"tags":       []any{"", "", ""},
+				"attributes": map[string]any{},
+				"history": []any{
+					map[string]any{"code": "C1"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.config.WithSchema(schema))
+			if got := s.SanitizeMap(input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SanitizeMap() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeJSONWithOpenAPISchema(t *testing.T) {
+	openapi := `{
+	  "openapi": "3.0.3",
+	  "info": {"title": "Customers", "version": "1"},
+	  "paths": {},
+	  "components": {
+	    "schemas": {
+	      "Address": {
+	        "type": "object",
+	        "properties": {
+	          "country": {"type": "string", "x-pii": "preserve"},
+	          "line1": {"type": "string", "x-pii": "address"}
+	        }
+	      },
+	      "CreateCustomerRequest": {
+	        "type": "object",
+	        "properties": {
+	          "contactEmail": {"type": "string", "format": "email"},
+	          "billing": {"$ref": "#/components/schemas/Address"},
+	          "memberId": {"type": "string", "x-pii": "redact"}
+	        }
+	      }
+	    }
+	  }
+	}`
+
+	schema, err := LoadOpenAPISchema([]byte(openapi), "CreateCustomerRequest")
+	if err != nil {
+		t.Fatalf("LoadOpenAPISchema() error = %v", err)
+	}
+	s := New(NewDefaultConfig().WithSchema(schema))
+
+	input := `{"contactEmail":"john@acme.com","billing":{"country":"SG","line1":"1 Raffles Place"},"memberId":"M-42","plan":"basic"}`
+	got, err := s.SanitizeJSON([]byte(input))
+	if err != nil {
+		t.Fatalf("SanitizeJSON() error = %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(got, &result); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := map[string]any{
+		"contactEmail": "[REDACTED]",
+		"billing":      map[string]any{"country": "SG", "line1": "[REDACTED]"},
+		"memberId":     "[REDACTED]",
+		"plan":         "basic",
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("SanitizeJSON() = %s, want %v", got, want)
+	}
+
+	swagger := `{"swagger": "2.0", "definitions": {"Login": {"properties": {"user": {"x-pii": "redact"}}}}}`
+	schema, err = LoadOpenAPISchema([]byte(swagger), "Login")
+	if err != nil {
+		t.Fatalf("LoadOpenAPISchema() error = %v", err)
+	}
+	if rules := schema.Rules(); rules["user"] != "redact" {
+		t.Errorf("Rules() = %v, want user redacted", rules)
+	}
+
+	if _, err := LoadOpenAPISchema([]byte(openapi), "Missing"); err == nil || !strings.Contains(err.Error(), `schema "Missing" not found`) {
+		t.Errorf("LoadOpenAPISchema() error = %v, want not found", err)
+	}
+}
+
+func TestWriterWithSchema(t *testing.T) {
+	schema, err := LoadJSONSchema([]byte(testCustomerSchema))
+	if err != nil {
+		t.Fatalf("LoadJSONSchema() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		config *Config
+		want   string
+	}{
+		{
+			name:   "full",
+			config: NewDefaultConfig(),
+			want: `{"login":"[REDACTED]","ref":"[REDACTED]","country":"SG","balance":"[REDACTED]","status":"active",` +
+				`"owner":{"handle":"[REDACTED]","manager":{"handle":"asmith"}},"tags":["[REDACTED]","[REDACTED]"],` +
+				`"history":[{"memo":"[REDACTED]","code":"C1"}],"note":null}`,
+		},
+		{
+			name:   "remove",
+			config: NewDefaultConfig().WithStrategy(StrategyRemove),
+			want: `{"country":"SG","status":"active","owner":{"manager":{"handle":"asmith"}},"tags":["",null],` +
+				`"history":[{"code":"C1"}],"note":null}`,
+		},
+	}
+
+	input := `{"login":"john@acme.com","ref":"CUST-1001","country":"SG","balance":1250.5,"status":"active",` +
+		`"owner":{"handle":"jdoe","manager":{"handle":"asmith"}},"tags":["vip",{"a":1}],` +
+		`"history":[{"memo":"called back","code":"C1"}],"note":null}`
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, New(tt.config.WithSchema(schema)))
+			if _, err := w.Write([]byte(input + "\n")); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if got := strings.TrimSuffix(buf.String(), "\n"); got != tt.want {
+				t.Errorf("Write() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
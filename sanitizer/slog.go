@@ -2,6 +2,7 @@ package sanitizer
 
 import (
 	"log/slog"
+	"time"
 )
 
 // SlogValue wraps data for sanitization in slog logging
@@ -12,13 +13,11 @@ type SlogValue struct {
 }
 
 // LogValue implements slog.LogValuer
-// This method is called by slog when the value is logged
+// This method is called by slog when the value is logged.
+// Structs, maps and slices are walked with reflection, so ints, times and
+// durations keep their native slog kinds.
 func (v SlogValue) LogValue() slog.Value {
 	switch val := v.data.(type) {
-	case map[string]any:
-		sanitized := v.sanitizer.SanitizeMap(val)
-		return mapToSlogValue(sanitized)
-
 	case string:
 		// If it's a string, check if it contains PII patterns
 		if v.sanitizer.contentMatcher.matches(val) {
@@ -27,35 +26,57 @@ func (v SlogValue) LogValue() slog.Value {
 		return slog.StringValue(val)
 
 	default:
-		// For structs and other types, convert to map first
-		sanitized := v.sanitizer.SanitizeStruct(val)
-		return mapToSlogValue(sanitized)
-	}
-}
-
-// mapToSlogValue recursively converts a sanitized map to slog.Value
-func mapToSlogValue(m map[string]any) slog.Value {
-	attrs := make([]slog.Attr, 0, len(m))
-	for k, v := range m {
-		attrs = append(attrs, slog.Any(k, convertToSlogValue(v)))
+		value, _ := v.sanitizer.walkStructured("", val)
+		return structuredToSlogValue(value)
 	}
-	return slog.GroupValue(attrs...)
 }
 
-// convertToSlogValue converts any to appropriate slog value
-func convertToSlogValue(v any) any {
+// structuredToSlogValue converts a sanitized tree to a slog.Value with native kinds
+func structuredToSlogValue(v any) slog.Value {
 	switch val := v.(type) {
-	case map[string]any:
-		return mapToSlogValue(val)
-	case []any:
-		// Convert slice elements
-		result := make([]any, len(val))
-		for i, item := range val {
-			result[i] = convertToSlogValue(item)
+	case structuredObject:
+		attrs := make([]slog.Attr, len(val))
+		for i, f := range val {
+			attrs[i] = slog.Attr{Key: f.Key, Value: structuredToSlogValue(f.Value)}
 		}
-		return result
+		return slog.GroupValue(attrs...)
+	case structuredArray:
+		// slog has no array kind; handlers encode slices of plain values natively
+		return slog.AnyValue(structuredToPlain(val))
+	case string:
+		return slog.StringValue(val)
+	case int:
+		return slog.IntValue(val)
+	case int8:
+		return slog.Int64Value(int64(val))
+	case int16:
+		return slog.Int64Value(int64(val))
+	case int32:
+		return slog.Int64Value(int64(val))
+	case int64:
+		return slog.Int64Value(val)
+	case uint:
+		return slog.Uint64Value(uint64(val))
+	case uint8:
+		return slog.Uint64Value(uint64(val))
+	case uint16:
+		return slog.Uint64Value(uint64(val))
+	case uint32:
+		return slog.Uint64Value(uint64(val))
+	case uint64:
+		return slog.Uint64Value(val)
+	case float32:
+		return slog.Float64Value(float64(val))
+	case float64:
+		return slog.Float64Value(val)
+	case bool:
+		return slog.BoolValue(val)
+	case time.Time:
+		return slog.TimeValue(val)
+	case time.Duration:
+		return slog.DurationValue(val)
 	default:
-		return val
+		return slog.AnyValue(val)
 	}
 }
 
@@ -0,0 +1,141 @@
+package sanitizer
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHandler is a slog.Handler middleware that sanitizes every record before
+// passing it to the wrapped handler. Unlike SlogAttr/SlogValue, it covers
+// attributes logged with plain slog calls such as slog.String("email", ...).
+//
+// Attributes keep their order and value kinds; only string content, groups and
+// structured values (maps, structs, slices, errors) are rewritten.
+//
+// Example:
+//
+//	s := NewDefault()
+//	logger := slog.New(NewSlogHandler(slog.NewJSONHandler(os.Stdout, nil), s))
+//	logger.Info("login", "email", "user@example.com") // email="[REDACTED]"
+type SlogHandler struct {
+	inner           slog.Handler
+	sanitizer       *Sanitizer
+	sanitizeMessage bool
+}
+
+// NewSlogHandler wraps inner so that all attributes are sanitized with s
+func NewSlogHandler(inner slog.Handler, s *Sanitizer) *SlogHandler {
+	return &SlogHandler{
+		inner:     inner,
+		sanitizer: s,
+	}
+}
+
+// WithMessageSanitization returns a copy of the handler that also sanitizes the log
+// message text, replacing only the PII inside it
+func (h *SlogHandler) WithMessageSanitization(enabled bool) *SlogHandler {
+	clone := *h
+	clone.sanitizeMessage = enabled
+	return &clone
+}
+
+// Enabled implements slog.Handler
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	msg := r.Message
+	if h.sanitizeMessage {
+		msg = h.sanitizer.sanitizeText(msg)
+	}
+
+	sanitized := slog.NewRecord(r.Time, r.Level, msg, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if attr, keep := h.sanitizer.sanitizeSlogAttr(a, 0); keep {
+			sanitized.AddAttrs(attr)
+		}
+		return true
+	})
+
+	return h.inner.Handle(ctx, sanitized)
+}
+
+// WithAttrs implements slog.Handler.
+// Attributes are sanitized once here rather than on every record.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.inner = h.inner.WithAttrs(h.sanitizer.sanitizeSlogAttrs(attrs, 0))
+	return &clone
+}
+
+// WithGroup implements slog.Handler
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.inner = h.inner.WithGroup(name)
+	return &clone
+}
+
+// sanitizeSlogAttrs sanitizes a list of attributes, dropping removed ones
+func (s *Sanitizer) sanitizeSlogAttrs(attrs []slog.Attr, depth int) []slog.Attr {
+	result := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if attr, keep := s.sanitizeSlogAttr(a, depth); keep {
+			result = append(result, attr)
+		}
+	}
+	return result
+}
+
+// sanitizeSlogAttr sanitizes a single attribute using its key for field name matching.
+// Returns false if the attribute should be dropped (StrategyRemove).
+func (s *Sanitizer) sanitizeSlogAttr(a slog.Attr, depth int) (slog.Attr, bool) {
+	if depth > s.config.MaxDepth {
+		return a, true
+	}
+
+	// Values wrapped with SlogValue are already sanitized by their LogValue method
+	if a.Value.Kind() == slog.KindLogValuer {
+		if _, ok := a.Value.Any().(SlogValue); ok {
+			return slog.Attr{Key: a.Key, Value: a.Value.Resolve()}, true
+		}
+	}
+
+	// Resolve LogValuers first so their output is sanitized
+	value := a.Value.Resolve()
+
+	switch value.Kind() {
+	case slog.KindString:
+		str := value.String()
+		sanitized := s.SanitizeField(a.Key, str)
+		if s.config.Strategy == StrategyRemove && sanitized == "" && str != "" {
+			return a, false
+		}
+		return slog.String(a.Key, sanitized), true
+
+	case slog.KindGroup:
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(s.sanitizeSlogAttrs(value.Group(), depth+1)...)}, true
+
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			// Keep an error value so handlers can still format and unwrap it
+			sanitized, keep := s.sanitizeError(a.Key, err)
+			if !keep {
+				return a, false
+			}
+			return slog.Any(a.Key, sanitized), true
+		}
+
+		// Maps, structs, slices and []byte are walked with native kinds preserved
+		walked, keep := s.walkStructured(a.Key, value.Any())
+		if !keep {
+			return a, false
+		}
+		return slog.Attr{Key: a.Key, Value: structuredToSlogValue(walked)}, true
+
+	default:
+		// Numbers, bools, durations and times carry no string content
+		return slog.Attr{Key: a.Key, Value: value}, true
+	}
+}
@@ -0,0 +1,231 @@
+package sanitizer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestSlogLogger returns a logger writing JSON through a SlogHandler into buf
+func newTestSlogLogger(s *Sanitizer, buf *bytes.Buffer) *slog.Logger {
+	return slog.New(NewSlogHandler(slog.NewJSONHandler(buf, nil), s))
+}
+
+func TestSlogHandler_PlainAttrs(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := newTestSlogLogger(s, &buf)
+
+	logger.Info("user action",
+		slog.String("email", "user@example.com"),
+		"fullName", "John Doe",
+		slog.String("note", "contact +6591234567"),
+		slog.String("orderId", "ORD-123"),
+	)
+
+	output := buf.String()
+	for _, pii := range []string{"user@example.com", "John Doe", "+6591234567"} {
+		if strings.Contains(output, pii) {
+			t.Errorf("Expected %q to be redacted, got: %s", pii, output)
+		}
+	}
+	if !strings.Contains(output, "ORD-123") {
+		t.Errorf("Expected orderId to be preserved, got: %s", output)
+	}
+}
+
+func TestSlogHandler_PreservesOrderAndKinds(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := newTestSlogLogger(s, &buf)
+
+	logger.Info("payment",
+		slog.Int("count", 3),
+		slog.String("email", "user@example.com"),
+		slog.Float64("amount", 10.5),
+		slog.Bool("ok", true),
+		slog.Duration("elapsed", time.Second),
+	)
+
+	output := buf.String()
+	countIdx := strings.Index(output, `"count":3`)
+	emailIdx := strings.Index(output, `"email":"[REDACTED]"`)
+	amountIdx := strings.Index(output, `"amount":10.5`)
+	okIdx := strings.Index(output, `"ok":true`)
+	elapsedIdx := strings.Index(output, `"elapsed":1000000000`)
+
+	if countIdx < 0 || emailIdx < 0 || amountIdx < 0 || okIdx < 0 || elapsedIdx < 0 {
+		t.Fatalf("Expected all attributes with native kinds, got: %s", output)
+	}
+	if !(countIdx < emailIdx && emailIdx < amountIdx && amountIdx < okIdx && okIdx < elapsedIdx) {
+		t.Errorf("Expected attribute order to be preserved, got: %s", output)
+	}
+}
+
+func TestSlogHandler_WithAttrsAndGroups(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := newTestSlogLogger(s, &buf).
+		With("email", "user@example.com").
+		WithGroup("request").
+		With("phone", "+6591234567")
+
+	logger.Info("handled",
+		slog.Group("customer",
+			slog.String("fullName", "Jane Smith"),
+			slog.Group("address", slog.String("street", "1 Main St")),
+			slog.String("tier", "gold"),
+		),
+	)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse output: %v", err)
+	}
+
+	if entry["email"] != "[REDACTED]" {
+		t.Errorf("Expected WithAttrs email to be redacted, got %v", entry["email"])
+	}
+
+	request := entry["request"].(map[string]any)
+	if request["phone"] != "[REDACTED]" {
+		t.Errorf("Expected grouped WithAttrs phone to be redacted, got %v", request["phone"])
+	}
+
+	customer := request["customer"].(map[string]any)
+	if customer["fullName"] != "[REDACTED]" {
+		t.Errorf("Expected nested group name to be redacted, got %v", customer["fullName"])
+	}
+	if customer["tier"] != "gold" {
+		t.Errorf("Expected tier to be preserved, got %v", customer["tier"])
+	}
+	address := customer["address"].(map[string]any)
+	if address["street"] != "[REDACTED]" {
+		t.Errorf("Expected nested street to be redacted, got %v", address["street"])
+	}
+}
+
+// testLogValuer is a LogValuer returning an email attribute
+type testLogValuer struct{ email string }
+
+func (v testLogValuer) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("email", v.email), slog.String("id", "U-1"))
+}
+
+func TestSlogHandler_ResolvesLogValuers(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := newTestSlogLogger(s, &buf)
+
+	logger.Info("user", "user", testLogValuer{email: "user@example.com"})
+
+	output := buf.String()
+	if strings.Contains(output, "user@example.com") {
+		t.Errorf("Expected LogValuer output to be sanitized, got: %s", output)
+	}
+	if !strings.Contains(output, "U-1") {
+		t.Errorf("Expected id to be preserved, got: %s", output)
+	}
+}
+
+func TestSlogHandler_SlogValueNotSanitizedTwice(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyHash))
+
+	var buf bytes.Buffer
+	logger := newTestSlogLogger(s, &buf)
+
+	logger.Info("user", "user", s.SlogValue(map[string]any{"email": "user@example.com"}))
+
+	expected := s.SanitizeField("email", "user@example.com")
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected single hash %s, got: %s", expected, buf.String())
+	}
+}
+
+func TestSlogHandler_AnyValues(t *testing.T) {
+	s := NewDefault()
+
+	type profile struct {
+		Email   string `json:"email"`
+		OrderID string `json:"orderId"`
+	}
+
+	var buf bytes.Buffer
+	logger := newTestSlogLogger(s, &buf)
+
+	logger.Info("values",
+		"profile", &profile{Email: "user@example.com", OrderID: "ORD-1"},
+		"data", map[string]any{"phone": "+6591234567"},
+		"contacts", []string{"user@example.com", "ORD-2"},
+		"err", errors.New("lookup failed for user@example.com"),
+		"raw", []byte("user@example.com"),
+	)
+
+	output := buf.String()
+	if strings.Contains(output, "user@example.com") || strings.Contains(output, "+6591234567") {
+		t.Errorf("Expected structured values to be sanitized, got: %s", output)
+	}
+	if !strings.Contains(output, "ORD-1") || !strings.Contains(output, "ORD-2") {
+		t.Errorf("Expected safe values to be preserved, got: %s", output)
+	}
+}
+
+func TestSlogHandler_MessageSanitization(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := newTestSlogLogger(s, &buf)
+	logger.Info("sent OTP to user@example.com")
+	if !strings.Contains(buf.String(), "user@example.com") {
+		t.Errorf("Expected message to be untouched by default, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	handler := NewSlogHandler(slog.NewJSONHandler(&buf, nil), s).WithMessageSanitization(true)
+	slog.New(handler).Info("sent OTP to user@example.com")
+	if !strings.Contains(buf.String(), `"msg":"sent OTP to [REDACTED]"`) {
+		t.Errorf("Expected PII in message to be replaced in place, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	slog.New(handler.WithMessageSanitization(false)).Info("sent OTP to user@example.com")
+	if !strings.Contains(buf.String(), "user@example.com") {
+		t.Errorf("Expected message sanitization to be switched off, got: %s", buf.String())
+	}
+}
+
+func TestSlogHandler_RemoveStrategy(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyRemove))
+
+	var buf bytes.Buffer
+	logger := newTestSlogLogger(s, &buf)
+	logger.Info("test", "email", "user@example.com", "orderId", "ORD-1")
+
+	output := buf.String()
+	if strings.Contains(output, `"email"`) {
+		t.Errorf("Expected email attribute to be removed, got: %s", output)
+	}
+	if !strings.Contains(output, "ORD-1") {
+		t.Errorf("Expected orderId to be preserved, got: %s", output)
+	}
+}
+
+func TestSlogHandler_Enabled(t *testing.T) {
+	s := NewDefault()
+	var buf bytes.Buffer
+	handler := NewSlogHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}), s)
+
+	logger := slog.New(handler)
+	logger.Info("ignored", "email", "user@example.com")
+	if buf.Len() != 0 {
+		t.Errorf("Expected info record to be filtered by inner handler level, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,596 @@
+package sanitizer
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sqlTokenKind classifies SQL tokens
+type sqlTokenKind int
+
+const (
+	sqlSpace sqlTokenKind = iota
+	sqlComment
+	sqlString
+	sqlNumber
+	sqlIdent
+	sqlQuotedIdent
+	sqlPlaceholder
+	sqlPunct
+)
+
+// sqlToken is a lexed SQL token
+type sqlToken struct {
+	kind sqlTokenKind
+	raw  string
+
+	// value is the unquoted content of strings and quoted identifiers
+	value string
+
+	// unterminated marks a string or quoted identifier that runs to the end of the query
+	unterminated bool
+
+	// ambiguous marks a string or double-quoted token that ends elsewhere if a
+	// backslash escapes the quote, as in MySQL ('O\'Brien')
+	ambiguous bool
+}
+
+// sqlComparisonOps precede a value compared with a column
+var sqlComparisonOps = map[string]bool{
+	"=": true, "<>": true, "!=": true, "<": true, ">": true, "<=": true, ">=": true,
+	"LIKE": true, "ILIKE": true,
+}
+
+// sqlParams maps placeholders to the columns inferred for them
+type sqlParams struct {
+	ordinal map[int]string    // ? and $N, by 1-based ordinal
+	named   map[string]string // :name and @name
+}
+
+// column returns the column for the argument at ordinal (1-based) with an optional
+// name. A named argument without an inferred column uses its name.
+func (p sqlParams) column(ordinal int, name string) string {
+	if name != "" {
+		if column := p.named[name]; column != "" {
+			return column
+		}
+		return name
+	}
+	return p.ordinal[ordinal]
+}
+
+// SanitizeSQL returns query with PII literals redacted, and a sanitized copy of its
+// arguments for logging. String and numeric literals are matched to column names where
+// the query shows them:
+//   - INSERT INTO users (email, phone) VALUES ('a@b.com', ?)
+//   - WHERE email = 'a@b.com', col <> ?, col LIKE ..., col IN (...), col BETWEEN ... AND ...
+//   - UPDATE ... SET email = ?
+//
+// Literals and arguments with a column are sanitized with SanitizeField, so field name
+// rules apply; the rest are checked against content patterns. Placeholders may be ?,
+// $N, :name or @name, and sql.NamedArg arguments are matched by name. Comments have
+// PII spans redacted.
+//
+// Strings follow standard SQL quoting: a doubled quote escapes the quote, and a
+// backslash is an escape only in E'...' strings. If a literal is not terminated, or
+// would end elsewhere with MySQL backslash escapes ('O\'Brien'), the rest of the
+// query has PII spans redacted.
+//
+// Redacted literals are written as quoted strings ('[REDACTED]'). Under StrategyRemove
+// they become empty strings and arguments keep their positions.
+//
+// Example:
+//
+//	query, args := s.SanitizeSQL("UPDATE users SET email = ? WHERE id = ?", []any{"john@acme.com", 42})
+//	// UPDATE users SET email = ? WHERE id = ?  ["[REDACTED]" 42]
+func (s *Sanitizer) SanitizeSQL(query string, args []any) (string, []any) {
+	sanitized, params := s.sanitizeSQLQuery(query)
+
+	result := make([]any, len(args))
+	for i, arg := range args {
+		if named, ok := arg.(sql.NamedArg); ok {
+			named.Value = s.sanitizeSQLArg(params.column(i+1, named.Name), named.Value)
+			result[i] = named
+			continue
+		}
+		result[i] = s.sanitizeSQLArg(params.column(i+1, ""), arg)
+	}
+	return sanitized, result
+}
+
+// sanitizeSQLQuery redacts PII literals in query and returns the columns inferred
+// for its placeholders
+func (s *Sanitizer) sanitizeSQLQuery(query string) (string, sqlParams) {
+	tokens := lexSQL(query)
+	columns := inferSQLColumns(tokens)
+
+	params := sqlParams{ordinal: map[int]string{}, named: map[string]string{}}
+	var b strings.Builder
+	b.Grow(len(query))
+	ordinal := 0
+	offset := 0
+	asText := false
+	for i, t := range tokens {
+		column := columns[i]
+		if !asText && (t.unterminated || t.ambiguous) {
+			// The rest of the query may be inside the literal; scan it as text but
+			// keep recording placeholders for the arguments
+			b.WriteString(s.sanitizeText(query[offset:]))
+			asText = true
+		}
+		offset += len(t.raw)
+		if asText && t.kind != sqlPlaceholder {
+			continue
+		}
+
+		switch t.kind {
+		case sqlPlaceholder:
+			switch t.raw[0] {
+			case '?':
+				ordinal++
+				params.ordinal[ordinal] = column
+			case '$':
+				n, _ := strconv.Atoi(t.raw[1:])
+				params.ordinal[n] = column
+			default:
+				params.named[t.raw[1:]] = column
+			}
+			if asText {
+				continue
+			}
+
+		case sqlString, sqlNumber:
+			value := t.raw
+			if t.kind == sqlString {
+				value = t.value
+			}
+			if sanitized := s.sanitizeSQLValue(column, value); sanitized != value {
+				b.WriteString(quoteSQLString(sanitized))
+				continue
+			}
+
+		case sqlQuotedIdent:
+			// Double-quoted strings in MySQL look like identifiers
+			if sanitized := s.sanitizeContent(t.value); sanitized != t.value {
+				b.WriteByte(t.raw[0])
+				b.WriteString(sanitized)
+				b.WriteByte(t.raw[0])
+				continue
+			}
+
+		case sqlComment:
+			b.WriteString(s.sanitizeText(t.raw))
+			continue
+		}
+		b.WriteString(t.raw)
+	}
+	return b.String(), params
+}
+
+// sanitizeSQLValue sanitizes a literal or argument value for its column, if known
+func (s *Sanitizer) sanitizeSQLValue(column, value string) string {
+	if column != "" {
+		return s.SanitizeField(column, value)
+	}
+	return s.sanitizeContent(value)
+}
+
+// sanitizeSQLArg sanitizes a query argument for its column. Strings and byte slices
+// are sanitized by value; other types are replaced with a redacted string only if
+// their formatted value is redacted.
+func (s *Sanitizer) sanitizeSQLArg(column string, arg any) any {
+	if valuer, ok := arg.(driver.Valuer); ok {
+		if value, err := valuer.Value(); err == nil {
+			arg = value
+		}
+	}
+
+	switch v := arg.(type) {
+	case nil, bool:
+		return v
+	case string:
+		return s.sanitizeSQLValue(column, v)
+	case []byte:
+		if sanitized := s.sanitizeSQLValue(column, string(v)); sanitized != string(v) {
+			return []byte(sanitized)
+		}
+		return v
+	}
+
+	formatted := fmt.Sprint(arg)
+	if sanitized := s.sanitizeSQLValue(column, formatted); sanitized != formatted {
+		return sanitized
+	}
+	return arg
+}
+
+// quoteSQLString quotes a string literal, doubling embedded quotes
+func quoteSQLString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// lexSQL splits a query into tokens whose raw text concatenates back to the query
+func lexSQL(query string) []sqlToken {
+	var tokens []sqlToken
+	for i := 0; i < len(query); {
+		start := i
+		kind := sqlPunct
+		terminated := true
+		ambiguous := false
+		c := query[i]
+		next := byte(0)
+		if i+1 < len(query) {
+			next = query[i+1]
+		}
+
+		switch {
+		case isSQLSpace(c):
+			kind = sqlSpace
+			for i < len(query) && isSQLSpace(query[i]) {
+				i++
+			}
+		case c == '-' && next == '-':
+			kind = sqlComment
+			i = strings.IndexByte(query[i:], '\n')
+			if i < 0 {
+				i = len(query)
+			} else {
+				i += start
+			}
+		case c == '/' && next == '*':
+			kind = sqlComment
+			if end := strings.Index(query[i+2:], "*/"); end >= 0 {
+				i += 2 + end + 2
+			} else {
+				i = len(query)
+			}
+		case c == '\'', (c == 'E' || c == 'e' || c == 'N' || c == 'n') && next == '\'':
+			kind = sqlString
+			if c != '\'' {
+				i++
+			}
+			quote := i
+			backslash := isSQLEscapeString(query[start:i])
+			i, terminated = scanSQLQuoted(query, quote, backslash)
+			if !backslash {
+				end, _ := scanSQLQuoted(query, quote, true)
+				ambiguous = end != i
+			}
+		case c == '"' || c == '`':
+			kind = sqlQuotedIdent
+			i, terminated = scanSQLQuoted(query, start, false)
+			if c == '"' {
+				end, _ := scanSQLQuoted(query, start, true)
+				ambiguous = end != i
+			}
+		case isSQLDigit(c) || (c == '.' && isSQLDigit(next)):
+			kind = sqlNumber
+			i = scanSQLNumber(query, i)
+		case c == '?':
+			kind = sqlPlaceholder
+			i++
+		case c == '$' && isSQLDigit(next):
+			kind = sqlPlaceholder
+			for i++; i < len(query) && isSQLDigit(query[i]); i++ {
+			}
+		case (c == ':' || c == '@') && isSQLIdentStart(next) && (start == 0 || query[start-1] != c):
+			kind = sqlPlaceholder
+			for i++; i < len(query) && isSQLIdentPart(query[i]); i++ {
+			}
+		case isSQLIdentStart(c):
+			kind = sqlIdent
+			for i < len(query) && isSQLIdentPart(query[i]) {
+				i++
+			}
+		default:
+			i++
+			switch query[start:min(i+1, len(query))] {
+			case "<=", ">=", "<>", "!=", "::", "||":
+				i++
+			}
+		}
+
+		t := sqlToken{kind: kind, raw: query[start:i], unterminated: !terminated, ambiguous: ambiguous}
+		switch kind {
+		case sqlString:
+			t.value = unquoteSQL(t.raw[strings.IndexByte(t.raw, '\''):], isSQLEscapeString(t.raw), terminated)
+		case sqlQuotedIdent:
+			t.value = unquoteSQL(t.raw, false, terminated)
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+// scanSQLQuoted returns the end of the quoted token starting at i, and whether it is
+// terminated. A doubled quote escapes the quote, and so does a backslash if backslash
+// is set (E'...' strings); an unterminated token runs to the end.
+func scanSQLQuoted(query string, i int, backslash bool) (int, bool) {
+	quote := query[i]
+	for i++; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1, true
+		}
+	}
+	return len(query), false
+}
+
+// unquoteSQL returns the content of a quoted token with escapes resolved
+func unquoteSQL(raw string, backslash, terminated bool) string {
+	quote := raw[0]
+	content := raw[1:]
+	if terminated {
+		content = content[:len(content)-1]
+	}
+	if !strings.ContainsRune(content, rune(quote)) && !(backslash && strings.Contains(content, `\`)) {
+		return content
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if ((backslash && c == '\\') || c == quote) && i+1 < len(content) {
+			i++
+			c = content[i]
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// isSQLEscapeString reports whether a string literal starting with raw is an E'...'
+// string, where backslashes escape characters
+func isSQLEscapeString(raw string) bool {
+	return raw != "" && (raw[0] == 'E' || raw[0] == 'e')
+}
+
+// scanSQLNumber returns the end of the numeric literal starting at i
+func scanSQLNumber(query string, i int) int {
+	if strings.HasPrefix(query[i:], "0x") || strings.HasPrefix(query[i:], "0X") {
+		for i += 2; i < len(query) && isSQLIdentPart(query[i]); i++ {
+		}
+		return i
+	}
+	for i < len(query) && (isSQLDigit(query[i]) || query[i] == '.') {
+		i++
+	}
+	if i < len(query) && (query[i] == 'e' || query[i] == 'E') {
+		j := i + 1
+		if j < len(query) && (query[j] == '+' || query[j] == '-') {
+			j++
+		}
+		if j < len(query) && isSQLDigit(query[j]) {
+			for i = j; i < len(query) && isSQLDigit(query[i]); i++ {
+			}
+		}
+	}
+	return i
+}
+
+// inferSQLColumns maps the indexes of literal and placeholder tokens to the column
+// each is assigned to or compared with
+func inferSQLColumns(tokens []sqlToken) map[int]string {
+	// Significant tokens, skipping whitespace and comments
+	var sig []int
+	for i, t := range tokens {
+		if t.kind != sqlSpace && t.kind != sqlComment {
+			sig = append(sig, i)
+		}
+	}
+	at := func(k int) sqlToken {
+		if k < 0 || k >= len(sig) {
+			return sqlToken{}
+		}
+		return tokens[sig[k]]
+	}
+
+	columns := make(map[int]string)
+	for k := range sig {
+		if isSQLKeyword(at(k), "INSERT") {
+			inferInsertColumns(tokens, sig, k, columns)
+		}
+	}
+	for k, i := range sig {
+		if !isSQLValue(tokens[i]) || columns[i] != "" {
+			continue
+		}
+		if column := comparedSQLColumn(at, k); column != "" {
+			columns[i] = column
+		}
+	}
+	return columns
+}
+
+// inferInsertColumns maps the values of INSERT ... (cols) VALUES (...), (...) to
+// their columns, starting from the INSERT keyword at sig[k]
+func inferInsertColumns(tokens []sqlToken, sig []int, k int, columns map[int]string) {
+	// Find the column list before VALUES
+	for k++; k < len(sig) && tokens[sig[k]].raw != "("; k++ {
+		if isSQLKeyword(tokens[sig[k]], "VALUES") || isSQLKeyword(tokens[sig[k]], "SELECT") || tokens[sig[k]].raw == ";" {
+			return
+		}
+	}
+	var names []string
+	for k++; k < len(sig) && tokens[sig[k]].raw != ")"; k++ {
+		if t := tokens[sig[k]]; t.kind == sqlIdent || t.kind == sqlQuotedIdent {
+			names = append(names, sqlIdentName(t))
+		}
+	}
+	k++
+	if k >= len(sig) || !(isSQLKeyword(tokens[sig[k]], "VALUES") || isSQLKeyword(tokens[sig[k]], "VALUE")) {
+		return
+	}
+
+	// Each row is a parenthesized list; a value made of a single literal or
+	// placeholder, optionally signed, gets the column at its position
+	for k++; k < len(sig) && tokens[sig[k]].raw == "("; k++ {
+		position, depth, valueStart := 0, 0, k+1
+		for k++; k < len(sig); k++ {
+			raw := tokens[sig[k]].raw
+			if raw == "(" {
+				depth++
+				continue
+			}
+			if depth > 0 {
+				if raw == ")" {
+					depth--
+				}
+				continue
+			}
+			if raw != "," && raw != ")" {
+				continue
+			}
+
+			value := sig[valueStart:k]
+			if len(value) == 2 && (tokens[value[0]].raw == "-" || tokens[value[0]].raw == "+") {
+				value = value[1:]
+			}
+			if len(value) == 1 && isSQLValue(tokens[value[0]]) && position < len(names) {
+				columns[value[0]] = names[position]
+			}
+			position++
+			valueStart = k + 1
+			if raw == ")" {
+				break
+			}
+		}
+		// Continue with the next row after a comma
+		if k+1 >= len(sig) || tokens[sig[k+1]].raw != "," {
+			return
+		}
+		k++
+	}
+}
+
+// comparedSQLColumn returns the column that the value at significant index k is
+// compared with: col = v, col NOT LIKE v, col IN (a, v), col BETWEEN a AND v or v = col
+func comparedSQLColumn(at func(int) sqlToken, k int) string {
+	// Skip a sign before a number
+	left := k - 1
+	if raw := at(left).raw; (raw == "-" || raw == "+") && at(k).kind == sqlNumber {
+		left--
+	}
+
+	op := at(left)
+	switch {
+	case isSQLComparison(op):
+		column := left - 1
+		if isSQLKeyword(at(column), "NOT") {
+			column--
+		}
+		return sqlColumnName(at(column))
+
+	case isSQLKeyword(op, "BETWEEN"):
+		return sqlColumnName(at(left - 1))
+
+	case isSQLKeyword(op, "AND") && isSQLValue(at(left-1)) && isSQLKeyword(at(left-2), "BETWEEN"):
+		return sqlColumnName(at(left - 3))
+
+	case op.raw == "," || op.raw == "(":
+		// Walk back over an IN list
+		j := left
+		for at(j).raw == "," || isSQLValue(at(j)) || at(j).raw == "-" {
+			j--
+		}
+		if at(j).raw != "(" || !isSQLKeyword(at(j-1), "IN") {
+			return ""
+		}
+		column := j - 2
+		if isSQLKeyword(at(column), "NOT") {
+			column--
+		}
+		return sqlColumnName(at(column))
+	}
+
+	// Reversed comparison: v = col or v = t.col, unless col is a function call
+	if !isSQLComparison(at(k + 1)) {
+		return ""
+	}
+	column := k + 2
+	for at(column+1).raw == "." && sqlColumnName(at(column+2)) != "" {
+		column += 2
+	}
+	if at(column+1).raw == "(" {
+		return ""
+	}
+	return sqlColumnName(at(column))
+}
+
+// sqlColumnName returns the name of an identifier token, or "" if it is not one
+func sqlColumnName(t sqlToken) string {
+	if t.kind != sqlIdent && t.kind != sqlQuotedIdent {
+		return ""
+	}
+	if t.kind == sqlIdent && sqlKeywords[strings.ToUpper(t.raw)] {
+		return ""
+	}
+	return sqlIdentName(t)
+}
+
+// sqlIdentName returns the name of an identifier token without quotes
+func sqlIdentName(t sqlToken) string {
+	if t.kind == sqlQuotedIdent {
+		return t.value
+	}
+	return t.raw
+}
+
+// sqlKeywords are reserved words that are never column names
+var sqlKeywords = map[string]bool{
+	"AND": true, "OR": true, "NOT": true, "WHERE": true, "SET": true, "ON": true,
+	"WHEN": true, "THEN": true, "ELSE": true, "CASE": true, "END": true, "SELECT": true,
+	"RETURNING": true, "HAVING": true, "IS": true, "NULL": true, "LIMIT": true, "OFFSET": true,
+}
+
+// isSQLValue reports whether t is a literal or placeholder
+func isSQLValue(t sqlToken) bool {
+	return t.kind == sqlString || t.kind == sqlNumber || t.kind == sqlPlaceholder
+}
+
+// isSQLComparison reports whether t is a comparison operator
+func isSQLComparison(t sqlToken) bool {
+	switch t.kind {
+	case sqlPunct:
+		return sqlComparisonOps[t.raw]
+	case sqlIdent:
+		return sqlComparisonOps[strings.ToUpper(t.raw)]
+	}
+	return false
+}
+
+// isSQLKeyword reports whether t is the unquoted keyword kw
+func isSQLKeyword(t sqlToken, kw string) bool {
+	return t.kind == sqlIdent && strings.EqualFold(t.raw, kw)
+}
+
+// isSQLSpace reports whether c is whitespace
+func isSQLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// isSQLDigit reports whether c is a decimal digit
+func isSQLDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isSQLIdentStart reports whether c can start an unquoted identifier
+func isSQLIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+// isSQLIdentPart reports whether c can continue an unquoted identifier
+func isSQLIdentPart(c byte) bool {
+	return isSQLIdentStart(c) || isSQLDigit(c) || c == '$'
+}
@@ -0,0 +1,386 @@
+package sanitizer
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// SQLSink receives sanitized SQL records
+type SQLSink func(ctx context.Context, rec *SQLRecord)
+
+// SQLLogOptions configures SQL statement logging
+type SQLLogOptions struct {
+	// MinDuration logs only statements that take at least this long, for slow-query
+	// logs. Zero logs every statement.
+	MinDuration time.Duration
+
+	// Sink receives each sanitized record. Defaults to SlogSQLSink(slog.Default()).
+	Sink SQLSink
+}
+
+// SQLRecord is a sanitized SQL statement execution
+type SQLRecord struct {
+	// Query is the statement with PII literals redacted (see SanitizeSQL)
+	Query string
+
+	// Args are the sanitized bound arguments in order
+	Args []SQLArg
+
+	Duration time.Duration
+
+	// Error is the sanitized error of a failed statement
+	Error string
+}
+
+// SQLArg is a sanitized bound argument
+type SQLArg struct {
+	// Name is the column the argument is bound to where the query shows it, else the
+	// argument's name, else its ordinal ("arg1")
+	Name  string
+	Value any
+}
+
+// LogValue implements slog.LogValuer
+func (r *SQLRecord) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("query", r.Query)}
+	if len(r.Args) > 0 {
+		args := make([]slog.Attr, len(r.Args))
+		for i, arg := range r.Args {
+			args[i] = slog.Any(arg.Name, arg.Value)
+		}
+		attrs = append(attrs, slog.Attr{Key: "args", Value: slog.GroupValue(args...)})
+	}
+	attrs = append(attrs, slog.Duration("duration", r.Duration))
+	if r.Error != "" {
+		attrs = append(attrs, slog.String("error", r.Error))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// SlogSQLSink returns an SQLSink that logs records to logger as an "sql" group,
+// at error level for failed statements and at info level otherwise
+func SlogSQLSink(logger *slog.Logger) SQLSink {
+	return func(ctx context.Context, rec *SQLRecord) {
+		level := slog.LevelInfo
+		if rec.Error != "" {
+			level = slog.LevelError
+		}
+		logger.LogAttrs(ctx, level, "sql query", slog.Any("sql", rec))
+	}
+}
+
+// WrapSQLDriver wraps a database/sql driver so that every executed statement is logged
+// with its query and bound arguments sanitized by SanitizeSQL. Arguments are logged by
+// the column they are bound to. The statements and arguments sent to the database are
+// not altered.
+//
+// Example:
+//
+//	sql.Register("postgres-logged", sanitizer.WrapSQLDriver(&pq.Driver{}, s, sanitizer.SQLLogOptions{
+//		MinDuration: 200 * time.Millisecond,
+//		Sink:        sanitizer.SlogSQLSink(logger),
+//	}))
+//	db, err := sql.Open("postgres-logged", dsn)
+func WrapSQLDriver(d driver.Driver, s *Sanitizer, opts SQLLogOptions) driver.Driver {
+	return &sqlDriver{Driver: d, logger: &sqlLogger{sanitizer: s, opts: opts.withDefaults()}}
+}
+
+// WrapSQLConnector wraps a driver.Connector like WrapSQLDriver, for use with sql.OpenDB.
+//
+// Example:
+//
+//	db := sql.OpenDB(sanitizer.WrapSQLConnector(connector, s, sanitizer.SQLLogOptions{}))
+func WrapSQLConnector(c driver.Connector, s *Sanitizer, opts SQLLogOptions) driver.Connector {
+	logger := &sqlLogger{sanitizer: s, opts: opts.withDefaults()}
+	return &sqlConnector{Connector: c, driver: &sqlDriver{Driver: c.Driver(), logger: logger}}
+}
+
+// sqlLogger sanitizes and sends SQL records
+type sqlLogger struct {
+	sanitizer *Sanitizer
+	opts      SQLLogOptions
+}
+
+// withDefaults fills in unset options
+func (o SQLLogOptions) withDefaults() SQLLogOptions {
+	if o.Sink == nil {
+		o.Sink = func(ctx context.Context, rec *SQLRecord) {
+			SlogSQLSink(slog.Default())(ctx, rec)
+		}
+	}
+	return o
+}
+
+// log sends a record for a statement that started at start, unless the driver
+// skipped it or it was faster than MinDuration
+func (l *sqlLogger) log(ctx context.Context, query string, args []driver.NamedValue, start time.Time, err error) {
+	duration := time.Since(start)
+	if errors.Is(err, driver.ErrSkip) || duration < l.opts.MinDuration {
+		return
+	}
+
+	s := l.sanitizer
+	sanitized, params := s.sanitizeSQLQuery(query)
+	rec := &SQLRecord{Query: sanitized, Args: make([]SQLArg, len(args)), Duration: duration}
+	for i, arg := range args {
+		name := params.column(arg.Ordinal, arg.Name)
+		value := s.sanitizeSQLArg(name, arg.Value)
+		if name == "" {
+			name = "arg" + strconv.Itoa(arg.Ordinal)
+		}
+		rec.Args[i] = SQLArg{Name: name, Value: value}
+	}
+	if err != nil {
+		rec.Error = s.Error(err).Error()
+	}
+	l.opts.Sink(ctx, rec)
+}
+
+// sqlDriver wraps a driver.Driver
+type sqlDriver struct {
+	driver.Driver
+	logger *sqlLogger
+}
+
+// Open implements driver.Driver
+func (d *sqlDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlConn{Conn: conn, logger: d.logger}, nil
+}
+
+// OpenConnector implements driver.DriverContext
+func (d *sqlDriver) OpenConnector(name string) (driver.Connector, error) {
+	dc, ok := d.Driver.(driver.DriverContext)
+	if !ok {
+		return &sqlDSNConnector{name: name, driver: d}, nil
+	}
+	c, err := dc.OpenConnector(name)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlConnector{Connector: c, driver: d}, nil
+}
+
+// sqlConnector wraps a driver.Connector
+type sqlConnector struct {
+	driver.Connector
+	driver *sqlDriver
+}
+
+// Connect implements driver.Connector
+func (c *sqlConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlConn{Conn: conn, logger: c.driver.logger}, nil
+}
+
+// Driver implements driver.Connector
+func (c *sqlConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// sqlDSNConnector is the connector for drivers without driver.DriverContext
+type sqlDSNConnector struct {
+	name   string
+	driver *sqlDriver
+}
+
+// Connect implements driver.Connector
+func (c *sqlDSNConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.name)
+}
+
+// Driver implements driver.Connector
+func (c *sqlDSNConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// sqlConn wraps a driver.Conn, logging statements executed directly on the connection
+type sqlConn struct {
+	driver.Conn
+	logger *sqlLogger
+}
+
+// Prepare implements driver.Conn
+func (c *sqlConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext implements driver.ConnPrepareContext
+func (c *sqlConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if cp, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = cp.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sqlStmt{Stmt: stmt, conn: c, query: query}, nil
+}
+
+// BeginTx implements driver.ConnBeginTx
+func (c *sqlConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if cb, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return cb.BeginTx(ctx, opts)
+	}
+	if opts.Isolation != 0 || opts.ReadOnly {
+		return nil, errors.New("sanitizer: driver does not support transaction options")
+	}
+	return c.Conn.Begin() //nolint:staticcheck // fallback for drivers without BeginTx
+}
+
+// ExecContext implements driver.ExecerContext
+func (c *sqlConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.logger.log(ctx, query, args, start, err)
+	return result, err
+}
+
+// QueryContext implements driver.QueryerContext
+func (c *sqlConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	c.logger.log(ctx, query, args, start, err)
+	return rows, err
+}
+
+// Ping implements driver.Pinger
+func (c *sqlConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// ResetSession implements driver.SessionResetter
+func (c *sqlConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid implements driver.Validator
+func (c *sqlConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue implements driver.NamedValueChecker
+func (c *sqlConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// sqlStmt wraps a prepared driver.Stmt, logging each execution
+type sqlStmt struct {
+	driver.Stmt
+	conn  *sqlConn
+	query string
+}
+
+// Exec implements driver.Stmt
+func (st *sqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return st.ExecContext(context.Background(), namedValues(args))
+}
+
+// Query implements driver.Stmt
+func (st *sqlStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return st.QueryContext(context.Background(), namedValues(args))
+}
+
+// ExecContext implements driver.StmtExecContext
+func (st *sqlStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	var result driver.Result
+	var err error
+	if se, ok := st.Stmt.(driver.StmtExecContext); ok {
+		result, err = se.ExecContext(ctx, args)
+	} else if values, valuesErr := plainValues(args); valuesErr != nil {
+		err = valuesErr
+	} else {
+		result, err = st.Stmt.Exec(values) //nolint:staticcheck // fallback for drivers without ExecContext
+	}
+	st.conn.logger.log(ctx, st.query, args, start, err)
+	return result, err
+}
+
+// QueryContext implements driver.StmtQueryContext
+func (st *sqlStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	var rows driver.Rows
+	var err error
+	if sq, ok := st.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = sq.QueryContext(ctx, args)
+	} else if values, valuesErr := plainValues(args); valuesErr != nil {
+		err = valuesErr
+	} else {
+		rows, err = st.Stmt.Query(values) //nolint:staticcheck // fallback for drivers without QueryContext
+	}
+	st.conn.logger.log(ctx, st.query, args, start, err)
+	return rows, err
+}
+
+// CheckNamedValue implements driver.NamedValueChecker, deferring to the statement's
+// checker, then the connection's
+func (st *sqlStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := st.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return st.conn.CheckNamedValue(nv)
+}
+
+// ColumnConverter implements driver.ColumnConverter
+func (st *sqlStmt) ColumnConverter(idx int) driver.ValueConverter {
+	if cc, ok := st.Stmt.(driver.ColumnConverter); ok { //nolint:staticcheck // passed through for drivers that use it
+		return cc.ColumnConverter(idx)
+	}
+	return driver.DefaultParameterConverter
+}
+
+// namedValues converts positional values to named values
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
+
+// plainValues converts named values to positional values for drivers that do not
+// support named parameters
+func plainValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("sanitizer: driver does not support named parameters")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
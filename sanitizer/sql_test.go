@@ -0,0 +1,440 @@
+package sanitizer
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSanitizeSQL(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		name      string
+		query     string
+		args      []any
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:      "insert literals",
+			query:     "INSERT INTO users (id, email, status) VALUES (7, 'john@acme.com', 'active')",
+			wantQuery: "INSERT INTO users (id, email, status) VALUES (7, '[REDACTED]', 'active')",
+		},
+		{
+			name:      "insert placeholders",
+			query:     "INSERT INTO users (id, fullName, phone, status) VALUES (?, ?, ?, ?)",
+			args:      []any{7, "John Doe", "+6591234567", "active"},
+			wantQuery: "INSERT INTO users (id, fullName, phone, status) VALUES (?, ?, ?, ?)",
+			wantArgs:  []any{7, "[REDACTED]", "[REDACTED]", "active"},
+		},
+		{
+			name:      "insert multiple rows",
+			query:     `INSERT INTO "users" ("id", "fullName") VALUES (1, 'John Doe'), (2, 'Jane Roe')`,
+			wantQuery: `INSERT INTO "users" ("id", "fullName") VALUES (1, '[REDACTED]'), (2, '[REDACTED]')`,
+		},
+		{
+			name:      "where equals",
+			query:     "SELECT id FROM users WHERE passport = 'E1234567' AND status = 'active'",
+			wantQuery: "SELECT id FROM users WHERE passport = '[REDACTED]' AND status = 'active'",
+		},
+		{
+			name:      "qualified column and reversed comparison",
+			query:     "SELECT * FROM users u WHERE 'John Doe' = u.fullName OR u.id > 10",
+			wantQuery: "SELECT * FROM users u WHERE '[REDACTED]' = u.fullName OR u.id > 10",
+		},
+		{
+			name:      "in list",
+			query:     "DELETE FROM users WHERE email IN ('a@acme.com', ?, 'b@acme.com')",
+			args:      []any{"c@acme.com"},
+			wantQuery: "DELETE FROM users WHERE email IN ('[REDACTED]', ?, '[REDACTED]')",
+			wantArgs:  []any{"[REDACTED]"},
+		},
+		{
+			name:      "between",
+			query:     "SELECT id FROM users WHERE dob BETWEEN '1980-01-01' AND ? AND id < 5",
+			args:      []any{"1990-12-31"},
+			wantQuery: "SELECT id FROM users WHERE dob BETWEEN '[REDACTED]' AND ? AND id < 5",
+			wantArgs:  []any{"[REDACTED]"},
+		},
+		{
+			name:      "not like",
+			query:     "SELECT id FROM users WHERE email NOT LIKE '%@acme.com'",
+			wantQuery: "SELECT id FROM users WHERE email NOT LIKE '[REDACTED]'",
+		},
+		{
+			name:      "update set",
+			query:     "UPDATE users SET phone = $2, status = 'verified' WHERE id = $1",
+			args:      []any{42, "+6591234567"},
+			wantQuery: "UPDATE users SET phone = $2, status = 'verified' WHERE id = $1",
+			wantArgs:  []any{42, "[REDACTED]"},
+		},
+		{
+			name:      "named args",
+			query:     "SELECT id FROM users WHERE email = :addr OR pin = @code",
+			args:      []any{sql.Named("addr", "john@acme.com"), sql.Named("code", 1234)},
+			wantQuery: "SELECT id FROM users WHERE email = :addr OR pin = @code",
+			wantArgs:  []any{sql.Named("addr", "[REDACTED]"), sql.Named("code", "[REDACTED]")},
+		},
+		{
+			name:      "named arg without column",
+			query:     "SELECT set_password(:password)",
+			args:      []any{sql.Named("password", "hunter2")},
+			wantQuery: "SELECT set_password(:password)",
+			wantArgs:  []any{sql.Named("password", "[REDACTED]")},
+		},
+		{
+			name:      "content without column",
+			query:     "SELECT lookup('4532015112830366', 'john@acme.com', 'plain')",
+			wantQuery: "SELECT lookup('[REDACTED]', '[REDACTED]', 'plain')",
+		},
+		{
+			name:      "escaped quotes",
+			query:     `INSERT INTO t (fullName, status) VALUES ('O''Brien', E'it\'s fine')`,
+			wantQuery: `INSERT INTO t (fullName, status) VALUES ('[REDACTED]', E'it\'s fine')`,
+		},
+		{
+			name:      "backslash ends a standard string",
+			query:     `INSERT INTO t (path, email) VALUES ('C:\', 'john@acme.com')`,
+			wantQuery: `INSERT INTO t (path, email) VALUES ('C:\', '[REDACTED]')`,
+		},
+		{
+			name:      "mysql backslash escaped quote",
+			query:     `SELECT * FROM users WHERE note = 'O\'Brien john@acme.com' AND phone = ?`,
+			args:      []any{"+6591234567"},
+			wantQuery: `SELECT * FROM users WHERE note = 'O\'Brien [REDACTED]' AND phone = ?`,
+			wantArgs:  []any{"[REDACTED]"},
+		},
+		{
+			name:      "mysql backslash escaped double quote",
+			query:     `UPDATE t SET note = "say \"hi\" to john@acme.com" WHERE id = 7`,
+			wantQuery: `UPDATE t SET note = "say \"hi\" to [REDACTED]" WHERE id = 7`,
+		},
+		{
+			name:      "escape string with backslashes",
+			query:     `SELECT id FROM t WHERE email = E'john\\@acme.com\''`,
+			wantQuery: `SELECT id FROM t WHERE email = '[REDACTED]'`,
+		},
+		{
+			name:      "unterminated literal",
+			query:     `UPDATE t SET status = 'sent to john@acme.com WHERE id = 7`,
+			wantQuery: `UPDATE t SET status = 'sent to [REDACTED] WHERE id = 7`,
+		},
+		{
+			name:      "comments",
+			query:     "SELECT 1 -- requested by john@acme.com\n/* ticket 42 */",
+			wantQuery: "SELECT 1 -- requested by [REDACTED]\n/* ticket 42 */",
+		},
+		{
+			name:      "placeholders inside strings are literal",
+			query:     "SELECT id FROM users WHERE status = '?' AND email = ?",
+			args:      []any{"john@acme.com"},
+			wantQuery: "SELECT id FROM users WHERE status = '?' AND email = ?",
+			wantArgs:  []any{"[REDACTED]"},
+		},
+		{
+			name:      "non-PII values kept",
+			query:     "SELECT id FROM orders WHERE total > 10.5 AND created_at > ?",
+			args:      []any{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), nil, true, []byte("raw")},
+			wantQuery: "SELECT id FROM orders WHERE total > 10.5 AND created_at > ?",
+			wantArgs:  []any{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), nil, true, []byte("raw")},
+		},
+		{
+			name:      "byte slice and valuer args",
+			query:     "INSERT INTO users (email, token) VALUES (?, ?)",
+			args:      []any{[]byte("john@acme.com"), sql.NullString{String: "abc123", Valid: true}},
+			wantQuery: "INSERT INTO users (email, token) VALUES (?, ?)",
+			wantArgs:  []any{[]byte("[REDACTED]"), "[REDACTED]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := s.SanitizeSQL(tt.query, tt.args)
+			if query != tt.wantQuery {
+				t.Errorf("SanitizeSQL() query:\n got %s\nwant %s", query, tt.wantQuery)
+			}
+			if len(tt.args) == 0 && len(args) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("SanitizeSQL() args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestSanitizeSQLConfig(t *testing.T) {
+	query := "INSERT INTO users (email, customer_code) VALUES ('john@acme.com', ?)"
+	args := []any{"C-1001"}
+
+	s := New(NewDefaultConfig().WithRedact("customer_code").WithStrategy(StrategyRemove))
+	gotQuery, gotArgs := s.SanitizeSQL(query, args)
+
+	wantQuery := "INSERT INTO users (email, customer_code) VALUES ('', ?)"
+	if gotQuery != wantQuery {
+		t.Errorf("SanitizeSQL() query = %s, want %s", gotQuery, wantQuery)
+	}
+	if !reflect.DeepEqual(gotArgs, []any{""}) {
+		t.Errorf("SanitizeSQL() args = %#v, want positions kept", gotArgs)
+	}
+	if args[0] != "C-1001" {
+		t.Error("SanitizeSQL() must not modify the caller's arguments")
+	}
+
+	s = New(NewDefaultConfig().WithPreserve("email"))
+	gotQuery, _ = s.SanitizeSQL(query, nil)
+	if !strings.Contains(gotQuery, "'john@acme.com'") {
+		t.Errorf("Expected preserved email column to be kept, got %s", gotQuery)
+	}
+}
+
+// fakeSQLDriver is an in-process driver. Its connections implement the
+// context interfaces only if context is set, so that both the direct and
+// the prepared statement paths of database/sql are exercised.
+type fakeSQLDriver struct {
+	context bool
+	queries []string
+}
+
+// errFakeSQLDuplicate is returned for statements against the "dupes" table
+var errFakeSQLDuplicate = errors.New("duplicate key value: john@acme.com")
+
+func (d *fakeSQLDriver) Open(string) (driver.Conn, error) {
+	conn := &fakeSQLConn{driver: d}
+	if d.context {
+		return &fakeSQLContextConn{conn}, nil
+	}
+	return conn, nil
+}
+
+func (d *fakeSQLDriver) run(query string) error {
+	d.queries = append(d.queries, query)
+	if strings.Contains(query, "dupes") {
+		return errFakeSQLDuplicate
+	}
+	return nil
+}
+
+type fakeSQLConn struct {
+	driver *fakeSQLDriver
+}
+
+func (c *fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeSQLStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeSQLConn) Close() error { return nil }
+
+func (c *fakeSQLConn) Begin() (driver.Tx, error) { return fakeSQLTx{}, nil }
+
+type fakeSQLContextConn struct {
+	*fakeSQLConn
+}
+
+func (c *fakeSQLContextConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if err := c.driver.run(query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeSQLContextConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if err := c.driver.run(query); err != nil {
+		return nil, err
+	}
+	return &fakeSQLRows{}, nil
+}
+
+type fakeSQLTx struct{}
+
+func (fakeSQLTx) Commit() error   { return nil }
+func (fakeSQLTx) Rollback() error { return nil }
+
+type fakeSQLStmt struct {
+	conn  *fakeSQLConn
+	query string
+}
+
+func (st *fakeSQLStmt) Close() error  { return nil }
+func (st *fakeSQLStmt) NumInput() int { return -1 }
+
+func (st *fakeSQLStmt) Exec([]driver.Value) (driver.Result, error) {
+	if err := st.conn.driver.run(st.query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (st *fakeSQLStmt) Query([]driver.Value) (driver.Rows, error) {
+	if err := st.conn.driver.run(st.query); err != nil {
+		return nil, err
+	}
+	return &fakeSQLRows{}, nil
+}
+
+type fakeSQLRows struct{}
+
+func (*fakeSQLRows) Columns() []string         { return []string{"id"} }
+func (*fakeSQLRows) Close() error              { return nil }
+func (*fakeSQLRows) Next([]driver.Value) error { return io.EOF }
+
+// fakeSQLConnector opens connections from a fakeSQLDriver
+type fakeSQLConnector struct {
+	driver *fakeSQLDriver
+}
+
+func (c fakeSQLConnector) Connect(context.Context) (driver.Conn, error) { return c.driver.Open("") }
+func (c fakeSQLConnector) Driver() driver.Driver                        { return c.driver }
+
+// captureSQLRecords returns options whose sink appends records to recs
+func captureSQLRecords(recs *[]*SQLRecord) SQLLogOptions {
+	return SQLLogOptions{Sink: func(_ context.Context, rec *SQLRecord) {
+		*recs = append(*recs, rec)
+	}}
+}
+
+func TestWrapSQLConnector(t *testing.T) {
+	for _, withContext := range []bool{true, false} {
+		name := "prepared"
+		if withContext {
+			name = "context"
+		}
+		t.Run(name, func(t *testing.T) {
+			s := NewDefault()
+			fake := &fakeSQLDriver{context: withContext}
+
+			var recs []*SQLRecord
+			db := sql.OpenDB(WrapSQLConnector(fakeSQLConnector{fake}, s, captureSQLRecords(&recs)))
+			defer db.Close()
+
+			query := "INSERT INTO users (id, email, status) VALUES (?, ?, 'active') -- by john@acme.com"
+			if _, err := db.Exec(query, 7, "jane@acme.com"); err != nil {
+				t.Fatalf("Exec() error = %v", err)
+			}
+			rows, err := db.Query("SELECT id FROM users WHERE phone = $1", "+6591234567")
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			_ = rows.Close()
+			if _, err := db.Exec("INSERT INTO dupes (email) VALUES (?)", "jane@acme.com"); err == nil {
+				t.Fatal("Expected driver error to be returned")
+			} else if !errors.Is(err, errFakeSQLDuplicate) {
+				t.Errorf("Expected driver error unchanged, got %v", err)
+			}
+
+			// The driver receives the original statements
+			if len(fake.queries) != 3 || fake.queries[0] != query {
+				t.Errorf("Expected driver to receive original queries, got %q", fake.queries)
+			}
+
+			if len(recs) != 3 {
+				t.Fatalf("Expected 3 records, got %d", len(recs))
+			}
+
+			insert := recs[0]
+			if insert.Query != "INSERT INTO users (id, email, status) VALUES (?, ?, 'active') -- by [REDACTED]" {
+				t.Errorf("Unexpected query: %s", insert.Query)
+			}
+			wantArgs := []SQLArg{{Name: "id", Value: int64(7)}, {Name: "email", Value: "[REDACTED]"}}
+			if !reflect.DeepEqual(insert.Args, wantArgs) {
+				t.Errorf("Args = %#v, want %#v", insert.Args, wantArgs)
+			}
+			if insert.Error != "" {
+				t.Errorf("Expected no error, got %s", insert.Error)
+			}
+
+			wantArgs = []SQLArg{{Name: "phone", Value: "[REDACTED]"}}
+			if !reflect.DeepEqual(recs[1].Args, wantArgs) {
+				t.Errorf("Args = %#v, want %#v", recs[1].Args, wantArgs)
+			}
+
+			failed := recs[2]
+			if failed.Error == "" || strings.Contains(failed.Error, "john@acme.com") {
+				t.Errorf("Expected sanitized error, got %q", failed.Error)
+			}
+		})
+	}
+}
+
+func TestWrapSQLDriver(t *testing.T) {
+	s := NewDefault()
+	fake := &fakeSQLDriver{context: true}
+
+	var recs []*SQLRecord
+	driverName := "sanitizer-fake-" + t.Name()
+	sql.Register(driverName, WrapSQLDriver(fake, s, captureSQLRecords(&recs)))
+
+	db, err := sql.Open(driverName, "")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if _, err := tx.Exec("UPDATE users SET fullName = :name WHERE id = :id", sql.Named("name", "John Doe"), sql.Named("id", 7)); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	if len(recs) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(recs))
+	}
+	wantArgs := []SQLArg{{Name: "fullName", Value: "[REDACTED]"}, {Name: "id", Value: int64(7)}}
+	if !reflect.DeepEqual(recs[0].Args, wantArgs) {
+		t.Errorf("Args = %#v, want %#v", recs[0].Args, wantArgs)
+	}
+}
+
+func TestSQLLogMinDuration(t *testing.T) {
+	s := NewDefault()
+	fake := &fakeSQLDriver{context: true}
+
+	var recs []*SQLRecord
+	opts := captureSQLRecords(&recs)
+	opts.MinDuration = time.Hour
+	db := sql.OpenDB(WrapSQLConnector(fakeSQLConnector{fake}, s, opts))
+	defer db.Close()
+
+	if _, err := db.Exec("DELETE FROM users WHERE email = ?", "john@acme.com"); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	if len(recs) != 0 {
+		t.Errorf("Expected fast statements to be skipped, got %d records", len(recs))
+	}
+}
+
+func TestSlogSQLSink(t *testing.T) {
+	s := NewDefault()
+	fake := &fakeSQLDriver{context: true}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	db := sql.OpenDB(WrapSQLConnector(fakeSQLConnector{fake}, s, SQLLogOptions{Sink: SlogSQLSink(logger)}))
+	defer db.Close()
+
+	_, _ = db.Exec("INSERT INTO dupes (id, email) VALUES (?, ?)", 7, "jane@acme.com")
+
+	out := buf.String()
+	if strings.Contains(out, "acme.com") {
+		t.Fatalf("Expected PII to be redacted, got %s", out)
+	}
+	for _, want := range []string{`"level":"ERROR"`, `"msg":"sql query"`, `"args":{"id":7,"email":"[REDACTED]"}`, `"error":"duplicate key value: [REDACTED]"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %s in %s", want, out)
+		}
+	}
+}
@@ -0,0 +1,369 @@
+package sanitizer
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type-preserving structured conversion shared by the logger adapters.
+//
+// SanitizeStruct round-trips through JSON, which turns ints into float64,
+// time.Time into strings and []byte into base64. walkStructured instead walks
+// the value with reflection and produces a sanitized tree of native values that
+// each adapter encodes with its own typed API:
+//
+//	string, bool, the basic int/uint/float types, time.Time, time.Duration,
+//	nil, structuredObject (ordered fields) and structuredArray.
+
+// structuredField is a sanitized key/value pair
+type structuredField struct {
+	Key   string
+	Value any
+}
+
+// structuredObject is an ordered list of sanitized fields.
+// Struct fields keep declaration order; map entries are sorted by key.
+type structuredObject []structuredField
+
+// structuredArray is a list of sanitized values
+type structuredArray []any
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	valuerType        = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	jsonNumberType    = reflect.TypeOf(json.Number(""))
+)
+
+// walkStructured sanitizes v into a tree of native values without a JSON round trip.
+// key is used for field name matching when v itself is a string, []byte or error.
+// Returns false if the value should be omitted (StrategyRemove).
+func (s *Sanitizer) walkStructured(key string, v any) (any, bool) {
+	if v == nil {
+		return nil, true
+	}
+	return s.walkValue(key, reflect.ValueOf(v), nil, 0)
+}
+
+// walkValue sanitizes a single value. fieldName drives field name matching for strings.
+// Returns false if the value should be omitted (StrategyRemove).
+func (s *Sanitizer) walkValue(fieldName string, val reflect.Value, tag *piiTag, depth int) (any, bool) {
+	if !val.IsValid() {
+		return nil, true
+	}
+
+	// Unwrap pointers and interfaces, checking for errors first since
+	// error methods are often declared on pointer receivers
+	for {
+		if (val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) && val.IsNil() {
+			return nil, true
+		}
+		if val.Kind() != reflect.Interface && val.Type().Implements(errorType) {
+			return s.walkError(fieldName, val.Interface().(error), tag != nil && tag.action == "redact")
+		}
+		if val.Kind() != reflect.Ptr && val.Kind() != reflect.Interface {
+			break
+		}
+		val = val.Elem()
+	}
+
+	if depth > s.config.MaxDepth {
+		return nil, true
+	}
+
+	if tag != nil && tag.action == "redact" {
+		if val.Kind() == reflect.String {
+			return s.walkString(fieldName, val.String(), true)
+		}
+		return "[REDACTED]", true
+	}
+	preserve := tag != nil && tag.action == "preserve"
+
+	// Well-known types first, before falling back to their underlying kinds
+	switch {
+	case val.Type() == timeType:
+		return val.Interface().(time.Time), true
+	case val.Type() == durationType:
+		return time.Duration(val.Int()), true
+	case val.Type() == jsonNumberType:
+		return jsonNumberValue(json.Number(val.String())), true
+	}
+	if value, keep, ok := s.walkMarshaler(fieldName, val, tag, depth); ok {
+		return value, keep
+	}
+
+	switch val.Kind() {
+	case reflect.String:
+		if preserve {
+			return val.String(), true
+		}
+		return s.walkString(fieldName, val.String(), false)
+
+	case reflect.Bool:
+		return val.Bool(), true
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return nativeNumber(val), true
+
+	case reflect.Struct:
+		return s.walkStruct(val, depth), true
+
+	case reflect.Map:
+		return s.walkMap(val, depth), true
+
+	case reflect.Slice, reflect.Array:
+		if val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8 {
+			// []byte is text in logs, not base64
+			if preserve {
+				return string(val.Bytes()), true
+			}
+			return s.walkString(fieldName, string(val.Bytes()), false)
+		}
+		return s.walkArray(val, depth), true
+
+	default:
+		// Complex numbers, channels and functions have no meaningful sanitized form
+		return fmt.Sprint(val.Interface()), true
+	}
+}
+
+// walkMarshaler sanitizes a value that encodes itself, like encoding/json would
+// encode it: as its JSON for structs implementing json.Marshaler, as text for
+// encoding.TextMarshaler, and as its database value for structs implementing
+// driver.Valuer (sql.NullString). Structs such as netip.Addr and decimal types keep
+// their state in unexported fields, so walking their fields would lose the value.
+// The last result is false if val has none of these methods or encoding fails.
+func (s *Sanitizer) walkMarshaler(fieldName string, val reflect.Value, tag *piiTag, depth int) (any, bool, bool) {
+	if m, ok := methodValue(val, jsonMarshalerType).(json.Marshaler); ok && val.Kind() == reflect.Struct {
+		data, err := m.MarshalJSON()
+		if err != nil {
+			return nil, false, false
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var decoded any
+		if err := decoder.Decode(&decoded); err != nil {
+			return nil, false, false
+		}
+		value, keep := s.walkValue(fieldName, reflect.ValueOf(decoded), tag, depth+1)
+		return value, keep, true
+	}
+
+	if m, ok := methodValue(val, textMarshalerType).(encoding.TextMarshaler); ok {
+		// e.g. net.IP, netip.Addr, UUID arrays
+		text, err := m.MarshalText()
+		if err != nil {
+			return nil, false, false
+		}
+		if tag != nil && tag.action == "preserve" {
+			return string(text), true, true
+		}
+		value, keep := s.walkString(fieldName, string(text), false)
+		return value, keep, true
+	}
+
+	if m, ok := methodValue(val, valuerType).(driver.Valuer); ok && val.Kind() == reflect.Struct {
+		dbValue, err := m.Value()
+		if err != nil {
+			return nil, false, false
+		}
+		value, keep := s.walkValue(fieldName, reflect.ValueOf(dbValue), tag, depth+1)
+		return value, keep, true
+	}
+	return nil, false, false
+}
+
+// methodValue returns val as an interface if val, or a pointer to it when it is
+// addressable, implements iface. Returns nil otherwise.
+func methodValue(val reflect.Value, iface reflect.Type) any {
+	if val.Type().Implements(iface) && val.CanInterface() {
+		return val.Interface()
+	}
+	if val.CanAddr() && reflect.PointerTo(val.Type()).Implements(iface) && val.Addr().CanInterface() {
+		return val.Addr().Interface()
+	}
+	return nil
+}
+
+// jsonNumberValue converts a JSON number to int64, uint64 or float64
+func jsonNumberValue(n json.Number) any {
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return u
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// nativeNumber converts a numeric value to its basic Go type (named types like
+// `type Status int` become int), so adapters can pick the matching typed encoder
+func nativeNumber(val reflect.Value) any {
+	switch val.Kind() {
+	case reflect.Int:
+		return int(val.Int())
+	case reflect.Int8:
+		return int8(val.Int())
+	case reflect.Int16:
+		return int16(val.Int())
+	case reflect.Int32:
+		return int32(val.Int())
+	case reflect.Int64:
+		return val.Int()
+	case reflect.Uint:
+		return uint(val.Uint())
+	case reflect.Uint8:
+		return uint8(val.Uint())
+	case reflect.Uint16:
+		return uint16(val.Uint())
+	case reflect.Uint32:
+		return uint32(val.Uint())
+	case reflect.Uint64:
+		return val.Uint()
+	case reflect.Uintptr:
+		return uintptr(val.Uint())
+	case reflect.Float32:
+		return float32(val.Float())
+	default:
+		return val.Float()
+	}
+}
+
+// walkString sanitizes a string value, applying the tag's forced redaction if set
+func (s *Sanitizer) walkString(fieldName, value string, forceRedact bool) (any, bool) {
+	var sanitized string
+	if forceRedact {
+		sanitized = s.redact(value)
+	} else {
+		sanitized = s.SanitizeField(fieldName, value)
+	}
+	if s.isRemoved(value, sanitized) {
+		return nil, false
+	}
+	return sanitized, true
+}
+
+// walkError sanitizes an error message, redacting only the PII spans inside it
+func (s *Sanitizer) walkError(fieldName string, err error, forceRedact bool) (any, bool) {
+	if forceRedact {
+		return s.walkString(fieldName, err.Error(), true)
+	}
+	sanitized, keep := s.sanitizeError(fieldName, err)
+	if !keep {
+		return nil, false
+	}
+	return sanitized.Error(), true
+}
+
+// walkStruct sanitizes struct fields in declaration order, honoring json and pii tags
+func (s *Sanitizer) walkStruct(val reflect.Value, depth int) structuredObject {
+	typ := val.Type()
+	result := make(structuredObject, 0, val.NumField())
+
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		fieldType := typ.Field(i)
+
+		// Skip unexported fields
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		name := fieldType.Name
+		jsonTag := fieldType.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		if tagName := strings.Split(jsonTag, ",")[0]; tagName != "" {
+			name = tagName
+		} else if fieldType.Anonymous {
+			// Embedded structs without a json name are flattened, as encoding/json does
+			embedded := field
+			for embedded.Kind() == reflect.Ptr && !embedded.IsNil() {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				result = append(result, s.walkStruct(embedded, depth)...)
+				continue
+			}
+		}
+
+		tag := parsePIITag(fieldType.Tag.Get(piiTagName))
+		if value, keep := s.walkValue(name, field, tag, depth+1); keep {
+			result = append(result, structuredField{Key: name, Value: value})
+		}
+	}
+
+	return result
+}
+
+// walkMap sanitizes map entries, sorted by key for deterministic output
+func (s *Sanitizer) walkMap(val reflect.Value, depth int) structuredObject {
+	keys := make([]string, 0, val.Len())
+	values := make(map[string]reflect.Value, val.Len())
+
+	iter := val.MapRange()
+	for iter.Next() {
+		key := fmt.Sprint(iter.Key().Interface())
+		keys = append(keys, key)
+		values[key] = iter.Value()
+	}
+	sort.Strings(keys)
+
+	result := make(structuredObject, 0, len(keys))
+	for _, key := range keys {
+		if value, keep := s.walkValue(key, values[key], nil, depth+1); keep {
+			result = append(result, structuredField{Key: key, Value: value})
+		}
+	}
+	return result
+}
+
+// walkArray sanitizes slice or array elements (content matching only, no field name)
+func (s *Sanitizer) walkArray(val reflect.Value, depth int) structuredArray {
+	result := make(structuredArray, 0, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		value, keep := s.walkValue("", val.Index(i), nil, depth+1)
+		if !keep {
+			// Removed strings stay as empty elements so indexes are preserved, like sanitizeSlice
+			value = ""
+		}
+		result = append(result, value)
+	}
+	return result
+}
+
+// structuredToPlain converts a sanitized tree to plain Go values (maps and slices),
+// for encoders that only accept interface values
+func structuredToPlain(v any) any {
+	switch val := v.(type) {
+	case structuredObject:
+		m := make(map[string]any, len(val))
+		for _, f := range val {
+			m[f.Key] = structuredToPlain(f.Value)
+		}
+		return m
+	case structuredArray:
+		result := make([]any, len(val))
+		for i, item := range val {
+			result[i] = structuredToPlain(item)
+		}
+		return result
+	default:
+		return val
+	}
+}
@@ -0,0 +1,252 @@
+package sanitizer
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// typedEvent exercises every value type the structured walker preserves
+type typedEvent struct {
+	Email     string        `json:"email"`
+	OrderID   string        `json:"orderId"`
+	Count     int           `json:"count"`
+	Size      uint32        `json:"size"`
+	Ratio     float64       `json:"ratio"`
+	Active    bool          `json:"active"`
+	CreatedAt time.Time     `json:"createdAt"`
+	Elapsed   time.Duration `json:"elapsed"`
+	Payload   []byte        `json:"payload"`
+	Err       error         `json:"err"`
+	IP        net.IP        `json:"ip"`
+	Secret    string        `json:"secret" pii:"redact"`
+	Internal  string        `json:"-"`
+}
+
+var typedEventTime = time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+func newTypedEvent() typedEvent {
+	return typedEvent{
+		Email:     "user@example.com",
+		OrderID:   "ORD-123",
+		Count:     42,
+		Size:      7,
+		Ratio:     0.5,
+		Active:    true,
+		CreatedAt: typedEventTime,
+		Elapsed:   1500 * time.Millisecond,
+		Payload:   []byte("call +6591234567"),
+		Err:       errors.New("no account for user@example.com"),
+		IP:        net.IPv4(10, 0, 0, 1),
+		Secret:    "hunter2",
+		Internal:  "hidden",
+	}
+}
+
+func TestWalkStructured_Types(t *testing.T) {
+	s := NewDefault()
+
+	value, keep := s.walkStructured("", newTypedEvent())
+	if !keep {
+		t.Fatal("Expected struct to be kept")
+	}
+	obj, ok := value.(structuredObject)
+	if !ok {
+		t.Fatalf("Expected structuredObject, got %T", value)
+	}
+
+	expected := structuredObject{
+		{Key: "email", Value: "[REDACTED]"},
+		{Key: "orderId", Value: "ORD-123"},
+		{Key: "count", Value: 42},
+		{Key: "size", Value: uint32(7)},
+		{Key: "ratio", Value: 0.5},
+		{Key: "active", Value: true},
+		{Key: "createdAt", Value: typedEventTime},
+		{Key: "elapsed", Value: 1500 * time.Millisecond},
+		{Key: "payload", Value: "[REDACTED]"},
+		{Key: "err", Value: "no account for [REDACTED]"},
+		{Key: "ip", Value: "10.0.0.1"},
+		{Key: "secret", Value: "[REDACTED]"},
+	}
+	if len(obj) != len(expected) {
+		t.Fatalf("Expected %d fields, got %d: %v", len(expected), len(obj), obj)
+	}
+	for i, want := range expected {
+		if obj[i] != want {
+			t.Errorf("Field %d: expected %#v, got %#v", i, want, obj[i])
+		}
+	}
+}
+
+func TestWalkStructured_MapsAndSlices(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyRemove))
+
+	value, _ := s.walkStructured("", map[string]any{
+		"phone":   "+6591234567",
+		"orderId": "ORD-1",
+		"items":   []any{"user@example.com", 3, map[string]any{"email": "a@b.com"}},
+	})
+
+	obj := value.(structuredObject)
+	if len(obj) != 2 || obj[0].Key != "items" || obj[1].Key != "orderId" {
+		t.Fatalf("Expected sorted keys with phone removed, got %v", obj)
+	}
+	items := obj[0].Value.(structuredArray)
+	if items[0] != "" || items[1] != 3 {
+		t.Errorf("Expected removed array string to stay as empty element, got %v", items)
+	}
+	if nested := items[2].(structuredObject); len(nested) != 0 {
+		t.Errorf("Expected nested email to be removed, got %v", nested)
+	}
+}
+
+// amount is a decimal-like struct that keeps its state in unexported fields
+type amount struct {
+	units int64
+	owner string
+}
+
+func (a amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{"units": a.units, "owner": a.owner})
+}
+
+func TestWalkStructured_Marshalers(t *testing.T) {
+	s := NewDefault()
+
+	value, _ := s.walkStructured("", struct {
+		Addr   netip.Addr     `json:"addr"`
+		Note   sql.NullString `json:"note"`
+		Email  sql.NullString `json:"email"`
+		Unset  sql.NullString `json:"unset"`
+		Amount amount         `json:"amount"`
+	}{
+		Addr:   netip.MustParseAddr("10.0.0.1"),
+		Note:   sql.NullString{String: "gift wrap", Valid: true},
+		Email:  sql.NullString{String: "user@example.com", Valid: true},
+		Amount: amount{units: 1<<60 + 1, owner: "user@example.com"},
+	})
+
+	expected := structuredObject{
+		{Key: "addr", Value: "10.0.0.1"},
+		{Key: "note", Value: "gift wrap"},
+		{Key: "email", Value: "[REDACTED]"},
+		{Key: "unset", Value: nil},
+		{Key: "amount", Value: structuredObject{
+			{Key: "owner", Value: "[REDACTED]"},
+			{Key: "units", Value: int64(1<<60 + 1)},
+		}},
+	}
+	if got := value.(structuredObject); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, got)
+	}
+}
+
+func TestSlogValue_NativeKinds(t *testing.T) {
+	s := NewDefault()
+
+	value := s.SlogValue(newTypedEvent()).LogValue()
+	attrs := value.Group()
+
+	kinds := map[string]slog.Kind{
+		"email":     slog.KindString,
+		"count":     slog.KindInt64,
+		"size":      slog.KindUint64,
+		"ratio":     slog.KindFloat64,
+		"active":    slog.KindBool,
+		"createdAt": slog.KindTime,
+		"elapsed":   slog.KindDuration,
+		"payload":   slog.KindString,
+	}
+	for _, attr := range attrs {
+		if want, ok := kinds[attr.Key]; ok && attr.Value.Kind() != want {
+			t.Errorf("%s: expected kind %v, got %v", attr.Key, want, attr.Value.Kind())
+		}
+	}
+	if attrs[0].Key != "email" || attrs[1].Key != "orderId" {
+		t.Errorf("Expected declaration order, got %v", attrs)
+	}
+}
+
+func TestZapObject_NativeTypes(t *testing.T) {
+	s := NewDefault()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core)
+
+	logger.Info("event",
+		zap.Object("event", s.ZapObject(newTypedEvent())),
+		s.ZapField("counts", []int{1, 2}),
+	)
+
+	ctx := logs.All()[0].ContextMap()
+	event := ctx["event"].(map[string]any)
+	if event["count"] != 42 {
+		t.Errorf("Expected int count, got %#v", event["count"])
+	}
+	if event["size"] != uint32(7) {
+		t.Errorf("Expected uint32 size, got %#v", event["size"])
+	}
+	if event["createdAt"] != typedEventTime {
+		t.Errorf("Expected time.Time, got %#v", event["createdAt"])
+	}
+	if event["elapsed"] != 1500*time.Millisecond {
+		t.Errorf("Expected time.Duration, got %#v", event["elapsed"])
+	}
+	if event["payload"] != "[REDACTED]" || event["err"] != "no account for [REDACTED]" {
+		t.Errorf("Expected payload and error to be redacted, got %v", event)
+	}
+
+	counts := ctx["counts"].([]any)
+	if len(counts) != 2 || counts[0] != 1 {
+		t.Errorf("Expected int array, got %#v", counts)
+	}
+}
+
+func TestZerologObject_NativeTypes(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	logger.Info().Object("event", s.ZerologObject(newTypedEvent())).Msg("")
+
+	var decoded struct {
+		Event map[string]json.RawMessage `json:"event"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, buf.String())
+	}
+
+	expected := map[string]string{
+		"email":     `"[REDACTED]"`,
+		"count":     `42`,
+		"size":      `7`,
+		"createdAt": `"2024-01-15T10:30:00Z"`,
+		"elapsed":   `1500`,
+		"payload":   `"[REDACTED]"`,
+		"ip":        `"10.0.0.1"`,
+	}
+	for key, want := range expected {
+		if got := string(decoded.Event[key]); got != want {
+			t.Errorf("%s: expected %s, got %s", key, want, got)
+		}
+	}
+
+	// Struct fields keep declaration order in the output
+	if !bytes.Contains(buf.Bytes(), []byte(`"event":{"email":"[REDACTED]","orderId":"ORD-123","count":42`)) {
+		t.Errorf("Expected declaration order, got %s", buf.String())
+	}
+}
@@ -0,0 +1,165 @@
+package sanitizer
+
+import (
+	"net/url"
+	"strings"
+)
+
+// SanitizeURL sanitizes a URL for logging, keeping its structure and encoding:
+//   - query parameters are matched by key against field name rules
+//   - path segments are checked against content rules (/users/john@acme.com/orders)
+//   - the fragment is sanitized like a query if it has key=value pairs
+//     (#access_token=...), and by content otherwise
+//   - userinfo passwords are always redacted, and the username by content
+//
+// The scheme and host are kept unchanged. Strings that do not parse as URLs are
+// sanitized as free text.
+//
+// Example:
+//
+//	s.SanitizeURL("https://api.example.com/users/john@acme.com?mobile=%2B6591234567&page=2")
+//	// https://api.example.com/users/[REDACTED]?mobile=[REDACTED]&page=2
+func (s *Sanitizer) SanitizeURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return s.sanitizeText(raw)
+	}
+
+	// Rebuilt like url.URL.String, but from the escaped components so that
+	// unchanged parts keep their original encoding
+	var b strings.Builder
+	if u.Scheme != "" {
+		b.WriteString(u.Scheme)
+		b.WriteByte(':')
+	}
+	if u.Opaque != "" {
+		// e.g. mailto:john@acme.com
+		b.WriteString(s.sanitizeRequestPath(u.Opaque))
+	} else {
+		if (u.Scheme != "" || u.Host != "" || u.User != nil) && (u.Host != "" || u.Path != "" || u.User != nil) {
+			b.WriteString("//")
+		}
+		if u.User != nil {
+			b.WriteString(s.sanitizeUserinfo(u.User))
+			b.WriteByte('@')
+		}
+		b.WriteString(u.Host)
+
+		path := u.EscapedPath()
+		if path != "" && path[0] != '/' && u.Host != "" {
+			b.WriteByte('/')
+		}
+		b.WriteString(s.sanitizeRequestPath(path))
+	}
+	if u.ForceQuery || u.RawQuery != "" {
+		b.WriteByte('?')
+		b.WriteString(s.sanitizeQuery(u.RawQuery))
+	}
+	if u.Fragment != "" {
+		b.WriteByte('#')
+		fragment := u.EscapedFragment()
+		if strings.Contains(fragment, "=") {
+			b.WriteString(s.sanitizeQuery(fragment))
+		} else {
+			b.WriteString(s.sanitizeRequestPath(fragment))
+		}
+	}
+	return b.String()
+}
+
+// SanitizeURLValues returns a sanitized copy of query parameters or form values,
+// matching each key against field name rules. Under StrategyRemove removed values
+// are dropped, and keys without remaining values are omitted.
+func (s *Sanitizer) SanitizeURLValues(values url.Values) url.Values {
+	result := make(url.Values, len(values))
+	for key, vals := range values {
+		for _, value := range vals {
+			sanitized := s.SanitizeField(key, value)
+			if !s.isRemoved(value, sanitized) {
+				result[key] = append(result[key], sanitized)
+			}
+		}
+	}
+	return result
+}
+
+// sanitizeUserinfo sanitizes the username by content and redacts the password as
+// [REDACTED] whatever the strategy
+func (s *Sanitizer) sanitizeUserinfo(user *url.Userinfo) string {
+	username := user.Username()
+	userinfo := bracketUnescaper.Replace(url.User(s.sanitizeText(username)).String())
+	if password, ok := user.Password(); ok {
+		if redacted := s.redactCredential(password); redacted != "" {
+			userinfo += ":" + redacted
+		}
+	}
+	return userinfo
+}
+
+// sanitizeQuery sanitizes a raw query string or form-urlencoded body, matching
+// each key against field name rules. Pairs keep their order and original
+// encoding unless sanitization changes them; removed pairs are dropped.
+func (s *Sanitizer) sanitizeQuery(raw string) string {
+	if raw == "" {
+		return raw
+	}
+
+	pairs := strings.Split(raw, "&")
+	out := pairs[:0]
+	for _, pair := range pairs {
+		rawKey, rawValue, hasValue := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			value = rawValue
+		}
+
+		var sanitized string
+		if hasValue {
+			sanitized = s.SanitizeField(key, value)
+		} else {
+			// A bare key such as ?user@example.com carries no field name
+			value = key
+			sanitized = s.sanitizeText(key)
+		}
+		switch {
+		case sanitized == value:
+			out = append(out, pair)
+		case s.isRemoved(value, sanitized):
+			continue
+		case hasValue:
+			out = append(out, rawKey+"="+escapeQueryComponent(sanitized))
+		default:
+			out = append(out, escapeQueryComponent(sanitized))
+		}
+	}
+	return strings.Join(out, "&")
+}
+
+// bracketUnescaper keeps redaction markers readable in escaped URL components.
+// '[' and ']' are the only characters of "[REDACTED]" that url escaping encodes.
+var bracketUnescaper = strings.NewReplacer("%5B", "[", "%5D", "]")
+
+// escapeQueryComponent escapes a sanitized query key or value, leaving brackets as-is
+func escapeQueryComponent(value string) string {
+	return bracketUnescaper.Replace(url.QueryEscape(value))
+}
+
+// sanitizeRequestPath sanitizes an escaped URL path segment by segment by content,
+// keeping the encoding of segments that carry no PII
+func (s *Sanitizer) sanitizeRequestPath(escaped string) string {
+	segments := strings.Split(escaped, "/")
+	for i, segment := range segments {
+		value, err := url.PathUnescape(segment)
+		if err != nil {
+			value = segment
+		}
+		if sanitized := s.sanitizeText(value); sanitized != value {
+			segments[i] = bracketUnescaper.Replace(url.PathEscape(sanitized))
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,194 @@
+package sanitizer
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// writerMaxLineLength caps how much of an unterminated line Writer buffers.
+// Longer lines are sanitized and written in chunks of this size.
+const writerMaxLineLength = 64 * 1024
+
+// Writer is an io.Writer that sanitizes output line by line before passing it on.
+// Each line is detected as JSON, logfmt or free text:
+//
+//   - JSON objects are sanitized field-aware, keeping key order and formatting
+//   - logfmt lines (key=value pairs) are sanitized field-aware, keeping quoting
+//   - anything else has PII spans redacted in place, keeping the surrounding text, and
+//     embedded key=value tokens follow field name rules, so that a line with a prefix
+//     such as log.LstdFlags still has "password=..." redacted
+//
+// msg, message, err and error fields are treated as free text in JSON and logfmt lines.
+// Writer buffers partial lines until their newline arrives and is safe for concurrent use.
+type Writer struct {
+	mu        sync.Mutex
+	out       io.Writer
+	sanitizer *Sanitizer
+	pending   []byte
+}
+
+// NewWriter returns a Writer that sanitizes everything written to it before writing to w.
+// Use it for output that bypasses the structured integrations, such as the standard
+// log package or fmt.Fprintf debugging:
+//
+//	s := NewDefault()
+//	log.SetOutput(NewWriter(os.Stderr, s))
+//	log.Printf("sent receipt to %s", "user@example.com") // "sent receipt to [REDACTED]"
+//
+// Call Flush before exiting to write a final line that has no trailing newline.
+func NewWriter(w io.Writer, s *Sanitizer) *Writer {
+	return &Writer{out: w, sanitizer: s}
+}
+
+// Write implements io.Writer. Complete lines are sanitized and written to the
+// underlying writer; a trailing partial line is buffered until it is completed.
+// It always reports len(p) bytes written unless the underlying writer fails.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending = append(w.pending, p...)
+
+	var out []byte
+	for {
+		idx := bytes.IndexByte(w.pending, '\n')
+		if idx < 0 {
+			break
+		}
+		out = w.appendLine(out, w.pending[:idx+1])
+		w.pending = w.pending[idx+1:]
+	}
+	for len(w.pending) >= writerMaxLineLength {
+		out = w.appendLine(out, w.pending[:writerMaxLineLength])
+		w.pending = w.pending[writerMaxLineLength:]
+	}
+
+	// Compact so the buffer does not keep growing behind the remaining partial line
+	w.pending = append([]byte(nil), w.pending...)
+
+	if len(out) > 0 {
+		if _, err := w.out.Write(out); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sanitizes and writes any buffered partial line
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.pending) == 0 {
+		return nil
+	}
+	out := w.appendLine(nil, w.pending)
+	w.pending = nil
+	_, err := w.out.Write(out)
+	return err
+}
+
+// appendLine sanitizes a single line (including its line ending, if any) and appends it to dst
+func (w *Writer) appendLine(dst, line []byte) []byte {
+	body := bytes.TrimRight(line, "\r\n")
+	ending := line[len(body):]
+
+	return append(append(dst, w.sanitizer.sanitizeLine(body)...), ending...)
+}
+
+// sanitizeLine sanitizes a line without its line ending, detecting its format
+func (s *Sanitizer) sanitizeLine(line []byte) []byte {
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) == 0 {
+		return line
+	}
+
+	if trimmed[0] == '{' {
+		sanitized, err := s.sanitizeJSONOrdered(trimmed, jsonOrderedOptions{text: textFieldNames})
+		if err == nil {
+			// Keep any indentation around the object
+			lead := bytes.Index(line, trimmed)
+			return append(append(append([]byte(nil), line[:lead]...), sanitized...), line[lead+len(trimmed):]...)
+		}
+	}
+
+	if sanitized, ok := s.sanitizeLogfmt(line, false); ok {
+		return sanitized
+	}
+
+	return []byte(s.sanitizeTextFields(string(line)))
+}
+
+// sanitizeTextFields sanitizes free text like sanitizeText, and also redacts the values
+// of embedded key=value tokens whose key matches field name rules, as in
+// "2024/01/15 10:30:00 login password=hunter2 user=bob". Values may be double-quoted.
+func (s *Sanitizer) sanitizeTextFields(text string) string {
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] != '=' {
+			continue
+		}
+
+		// The key runs back to whitespace or the start of the text
+		start := i
+		for start > last && text[start-1] > ' ' && text[start-1] != '"' && text[start-1] != '=' {
+			start--
+		}
+		key := text[start:i]
+		if key == "" || (start > 0 && text[start-1] > ' ') || textFieldNames[strings.ToLower(key)] {
+			continue
+		}
+		keyLower := strings.ToLower(key)
+		if s.explicitSafe[keyLower] || !(s.explicitRedact[keyLower] || s.fieldMatcher.matches(key)) {
+			continue
+		}
+
+		// The value is a quoted string or runs to the next whitespace
+		end := i + 1
+		value, quoted := "", false
+		if end < len(text) && text[end] == '"' {
+			j := end + 1
+			for j < len(text) && text[j] != '"' {
+				if text[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j < len(text) {
+				if unquoted, err := strconv.Unquote(text[end : j+1]); err == nil {
+					value, quoted, end = unquoted, true, j+1
+				}
+			}
+		}
+		if !quoted {
+			for end < len(text) && text[end] > ' ' {
+				end++
+			}
+			value = text[i+1 : end]
+		}
+		if value == "" {
+			continue
+		}
+
+		redacted := s.redact(value)
+		if s.isRemoved(value, redacted) {
+			// Drop the pair with the whitespace before it
+			cut := start
+			for cut > last && (text[cut-1] == ' ' || text[cut-1] == '\t') {
+				cut--
+			}
+			b.WriteString(s.sanitizeText(text[last:cut]))
+		} else {
+			b.WriteString(s.sanitizeText(text[last : i+1]))
+			b.Write(appendLogfmtValue(nil, redacted, quoted))
+		}
+		last = end
+		i = end - 1
+	}
+	b.WriteString(s.sanitizeText(text[last:]))
+	return b.String()
+}
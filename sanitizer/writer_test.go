@@ -0,0 +1,266 @@
+package sanitizer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWriter_Formats(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "free text",
+			input:    "sent receipt to user@example.com for ORD-123\n",
+			expected: "sent receipt to [REDACTED] for ORD-123\n",
+		},
+		{
+			name:     "free text with equals sign",
+			input:    "retry=3 failed for user@example.com\n",
+			expected: "retry=3 failed for [REDACTED]\n",
+		},
+		{
+			name:     "logfmt",
+			input:    `level=info msg="sent to user@example.com" email=user@example.com orderId=ORD-1` + "\n",
+			expected: `level=info msg="sent to [REDACTED]" email=[REDACTED] orderId=ORD-1` + "\n",
+		},
+		{
+			name:     "logfmt field name match",
+			input:    `fullName="John Doe" tier=gold` + "\n",
+			expected: `fullName="[REDACTED]" tier=gold` + "\n",
+		},
+		{
+			name:     "json",
+			input:    `{"level":"INFO","msg":"sent to user@example.com","email":"user@example.com","amount":1.50}` + "\n",
+			expected: `{"level":"INFO","msg":"sent to [REDACTED]","email":"[REDACTED]","amount":1.50}` + "\n",
+		},
+		{
+			name:     "json-like free text",
+			input:    "{not json} user@example.com\n",
+			expected: "{not json} [REDACTED]\n",
+		},
+		{
+			name:     "crlf line ending",
+			input:    "phone +6591234567\r\n",
+			expected: "phone [REDACTED]\r\n",
+		},
+		{
+			name:     "no pii",
+			input:    "ts=1705315800.123 caller=main.go:42 id=12345678\n",
+			expected: "ts=1705315800.123 caller=main.go:42 id=12345678\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, s)
+
+			n, err := w.Write([]byte(tt.input))
+			if err != nil || n != len(tt.input) {
+				t.Fatalf("Write() = %d, %v", n, err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("got %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriter_StandardLog(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := log.New(NewWriter(&buf, s), "app: ", 0)
+
+	logger.Printf("login by %s from +6591234567", "user@example.com")
+
+	expected := "app: login by [REDACTED] from [REDACTED]\n"
+	if buf.String() != expected {
+		t.Errorf("got %q, want %q", buf.String(), expected)
+	}
+}
+
+func TestWriter_StandardLogFlags(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := log.New(NewWriter(&buf, s), "", log.LstdFlags)
+
+	logger.Printf("login password=hunter2 user=bob")
+	logger.Printf(`retry token="abc def" for user@example.com`)
+
+	out := buf.String()
+	for _, leaked := range []string{"hunter2", "abc def", "user@example.com"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("Expected %q to be redacted, got %q", leaked, out)
+		}
+	}
+	for _, want := range []string{" login password=[REDACTED] user=bob\n", ` retry token="[REDACTED]" for [REDACTED]` + "\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in %q", want, out)
+		}
+	}
+}
+
+func TestWriter_PartialWrites(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, s)
+
+	for _, chunk := range []string{"email=user@exa", "mple.com order", "Id=ORD-1\nsecond ", "line user@example.com"} {
+		if _, err := fmt.Fprint(w, chunk); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	if buf.String() != "email=[REDACTED] orderId=ORD-1\n" {
+		t.Errorf("Expected only the complete line to be written, got %q", buf.String())
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if buf.String() != "email=[REDACTED] orderId=ORD-1\nsecond line [REDACTED]" {
+		t.Errorf("Expected Flush to write the partial line, got %q", buf.String())
+	}
+}
+
+func TestWriter_LongLine(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, s)
+
+	long := strings.Repeat("a", writerMaxLineLength+10)
+	if _, err := w.Write([]byte(long)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if buf.Len() != writerMaxLineLength {
+		t.Errorf("Expected unterminated long line to be written in chunks, got %d bytes", buf.Len())
+	}
+}
+
+func TestWriter_RemoveStrategy(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyRemove))
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"email=user@example.com orderId=ORD-1\n", "orderId=ORD-1\n"},
+		{"orderId=ORD-1 email=user@example.com\n", "orderId=ORD-1\n"},
+		{`{"email":"user@example.com","orderId":"ORD-1"}` + "\n", `{"orderId":"ORD-1"}` + "\n"},
+		{"2024/01/15 10:30:00 login password=hunter2 user=bob\n", "2024/01/15 10:30:00 login user=bob\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if _, err := NewWriter(&buf, s).Write([]byte(tt.input)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("got %q, want %q", buf.String(), tt.expected)
+		}
+	}
+}
+
+func TestWriter_Concurrent(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, s)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				// Write each line in two parts to exercise buffering
+				_, _ = fmt.Fprintf(w, "worker=%d email=", i)
+				_, _ = fmt.Fprint(w, "user@example.com\n")
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if strings.Contains(buf.String(), "user@example.com") {
+		t.Error("Expected all emails to be redacted")
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 1000 {
+		t.Errorf("Expected 1000 lines, got %d", lines)
+	}
+}
+
+// failingWriter always returns an error
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestWriter_UnderlyingError(t *testing.T) {
+	w := NewWriter(failingWriter{}, NewDefault())
+
+	if _, err := w.Write([]byte("line\n")); err == nil {
+		t.Error("Expected error from underlying writer")
+	}
+	if _, err := w.Write([]byte("partial")); err != nil {
+		t.Errorf("Expected buffered write to succeed, got %v", err)
+	}
+	if err := w.Flush(); err == nil {
+		t.Error("Expected Flush to report the underlying error")
+	}
+}
+
+func TestSanitizeText(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"no pii here", "no pii here"},
+		{"mail user@example.com now", "mail [REDACTED] now"},
+		{"a@b.com, c@d.com", "[REDACTED], [REDACTED]"},
+		// Luhn-invalid numbers are not cards
+		{"ref 1234567812345678", "ref 1234567812345678"},
+		{"card 4532015112830366 ok", "card [REDACTED] ok"},
+	}
+
+	for _, tt := range tests {
+		if got := s.sanitizeText(tt.input); got != tt.expected {
+			t.Errorf("sanitizeText(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseLogfmt(t *testing.T) {
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{`a=1 b="two words" c=`, true},
+		{`a="escaped \" quote"`, true},
+		{`a=1 free text`, false},
+		{`a="unterminated`, false},
+		{`a="x"y`, false},
+		{`=1`, false},
+		{``, false},
+	}
+
+	for _, tt := range tests {
+		if _, ok := parseLogfmt([]byte(tt.input), false); ok != tt.valid {
+			t.Errorf("parseLogfmt(%q) valid = %v, want %v", tt.input, ok, tt.valid)
+		}
+	}
+}
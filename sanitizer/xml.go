@@ -0,0 +1,278 @@
+package sanitizer
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// xmlFieldAliases maps abbreviated XML element names, mostly from ISO 20022
+// (pain.001, pacs.008, camt.053) and WS-Security, to the field names they stand for.
+// Keys are lowercase.
+var xmlFieldAliases = map[string]string{
+	// Party names: Dbtr/Nm, Cdtr/Nm, UltmtDbtr/Nm
+	"nm": "fullName",
+
+	// Postal addresses: PstlAdr
+	"adrline":     "address",
+	"strtnm":      "street",
+	"bldgnb":      "address",
+	"bldgnm":      "address",
+	"flr":         "address",
+	"room":        "address",
+	"pstbx":       "address",
+	"pstcd":       "postalCode",
+	"twnnm":       "city",
+	"twnlctnnm":   "city",
+	"dstrctnm":    "city",
+	"ctrysubdvsn": "state",
+	"cityofbirth": "city",
+
+	// Contact details: CtctDtls
+	"emailadr": "email",
+	"phnenb":   "phone",
+	"mobnb":    "mobile",
+	"faxnb":    "phone",
+
+	// Private identification: DtAndPlcOfBirth
+	"birthdt": "birthDate",
+
+	// Unstructured remittance information: RmtInf/Ustrd
+	"ustrd": "paymentReference",
+
+	// Card payments: Card/PAN
+	"pan": "cardNumber",
+
+	// WS-Security: wsse:BinarySecurityToken
+	"binarysecuritytoken": "token",
+}
+
+// SanitizeXML streams an XML document from r to w with PII redacted, for payloads such
+// as ISO 20022 messages (pain.001, pacs.008, camt.053) and SOAP envelopes:
+//   - text is sanitized by the local name of its enclosing element, and attribute values
+//     by the attribute's local name, using the field name rules and then content rules
+//   - ISO 20022 abbreviations are understood: Nm as a name, AdrLine, StrtNm, PstCd and
+//     TwnNm as address, Ustrd as a payment reference, PAN as a card number; IBAN and
+//     WS-Security Password match directly
+//   - comments have PII spans redacted in place
+//
+// Namespace prefixes and declarations, element order, whitespace, processing
+// instructions and directives are written as read. CDATA sections are written as
+// escaped text and attribute values are always double-quoted. Under StrategyRemove
+// redacted text is left empty and redacted attributes are dropped.
+//
+// Returns an error if the input is not well-formed; the output written before the
+// error is flushed to w.
+//
+// Example:
+//
+//	err := s.SanitizeXML(bytes.NewReader(pain001), &out)
+//	// <Dbtr><Nm>[REDACTED]</Nm><PstlAdr><AdrLine>[REDACTED]</AdrLine></PstlAdr></Dbtr>
+func (s *Sanitizer) SanitizeXML(r io.Reader, w io.Writer) error {
+	x := &xmlSanitizer{sanitizer: s, dec: xml.NewDecoder(r), out: bufio.NewWriter(w)}
+	err := x.run()
+	if flushErr := x.out.Flush(); err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// xmlSanitizer holds the state of a SanitizeXML stream
+type xmlSanitizer struct {
+	sanitizer *Sanitizer
+	dec       *xml.Decoder
+	out       *bufio.Writer
+
+	// open lists the elements not yet closed. RawToken keeps namespace prefixes
+	// as written but does not check that end tags match, so it is done here.
+	open []xml.Name
+
+	// pending is set while a start tag is written without its closing '>',
+	// until the next token shows whether the element was self-closing
+	pending       bool
+	pendingOffset int64
+}
+
+// run copies tokens from the decoder to the output until EOF or an error
+func (x *xmlSanitizer) run() error {
+	for {
+		tok, err := x.dec.RawToken()
+		if x.pending {
+			x.pending = false
+			// A self-closing element is followed by a synthesized end
+			// element that consumes no input
+			if end, ok := tok.(xml.EndElement); ok && err == nil && x.dec.InputOffset() == x.pendingOffset {
+				if err := x.close(end.Name); err != nil {
+					return err
+				}
+				x.out.WriteString("/>")
+				continue
+			}
+			x.out.WriteByte('>')
+		}
+		if err == io.EOF {
+			if len(x.open) > 0 {
+				return x.syntaxError("unexpected EOF")
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			x.sanitizer.writeXMLStart(x.out, t)
+			x.open = append(x.open, t.Name)
+			x.pending = true
+			x.pendingOffset = x.dec.InputOffset()
+
+		case xml.EndElement:
+			if err := x.close(t.Name); err != nil {
+				return err
+			}
+			x.out.WriteString("</")
+			x.out.WriteString(xmlName(t.Name))
+			x.out.WriteByte('>')
+
+		case xml.CharData:
+			text := string(t)
+			if len(x.open) > 0 {
+				text = x.sanitizer.sanitizeXMLText(x.open[len(x.open)-1].Local, text)
+			} else {
+				text = x.sanitizer.sanitizeText(text)
+			}
+			writeXMLEscaped(x.out, text, false)
+
+		case xml.Comment:
+			x.out.WriteString("<!--")
+			x.out.WriteString(x.sanitizer.sanitizeText(string(t)))
+			x.out.WriteString("-->")
+
+		case xml.ProcInst:
+			x.out.WriteString("<?")
+			x.out.WriteString(t.Target)
+			if len(t.Inst) > 0 {
+				x.out.WriteByte(' ')
+				x.out.Write(t.Inst)
+			}
+			x.out.WriteString("?>")
+
+		case xml.Directive:
+			x.out.WriteString("<!")
+			x.out.Write(t)
+			x.out.WriteByte('>')
+		}
+	}
+}
+
+// close pops the innermost open element, checking that it matches the end tag
+func (x *xmlSanitizer) close(name xml.Name) error {
+	if len(x.open) == 0 {
+		return x.syntaxError(fmt.Sprintf("unexpected end element </%s>", xmlName(name)))
+	}
+	start := x.open[len(x.open)-1]
+	if start != name {
+		return x.syntaxError(fmt.Sprintf("element <%s> closed by </%s>", xmlName(start), xmlName(name)))
+	}
+	x.open = x.open[:len(x.open)-1]
+	return nil
+}
+
+// syntaxError returns an *xml.SyntaxError at the decoder's current line
+func (x *xmlSanitizer) syntaxError(msg string) error {
+	line, _ := x.dec.InputPos()
+	return &xml.SyntaxError{Msg: msg, Line: line}
+}
+
+// writeXMLStart writes a start tag with sanitized attributes, without the closing '>'
+func (s *Sanitizer) writeXMLStart(out *bufio.Writer, t xml.StartElement) {
+	out.WriteByte('<')
+	out.WriteString(xmlName(t.Name))
+	for _, attr := range t.Attr {
+		value := attr.Value
+		if !isXMLNamespaceAttr(attr.Name) {
+			value = s.sanitizeXMLValue(attr.Name.Local, attr.Value)
+			if s.isRemoved(attr.Value, value) {
+				continue
+			}
+		}
+		out.WriteByte(' ')
+		out.WriteString(xmlName(attr.Name))
+		out.WriteString(`="`)
+		writeXMLEscaped(out, value, true)
+		out.WriteByte('"')
+	}
+}
+
+// sanitizeXMLText sanitizes the text of an element, keeping surrounding whitespace
+func (s *Sanitizer) sanitizeXMLText(element, text string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	sanitized := s.sanitizeXMLValue(element, trimmed)
+	if sanitized == trimmed {
+		return text
+	}
+	lead := text[:strings.Index(text, trimmed)]
+	trail := text[len(lead)+len(trimmed):]
+	return lead + sanitized + trail
+}
+
+// sanitizeXMLValue sanitizes an element or attribute value by its local name,
+// falling back to the ISO 20022 alias for the name
+func (s *Sanitizer) sanitizeXMLValue(name, value string) string {
+	sanitized := s.SanitizeField(name, value)
+	lower := strings.ToLower(name)
+	if sanitized == value && !s.explicitSafe[lower] {
+		if alias, ok := xmlFieldAliases[lower]; ok {
+			sanitized = s.SanitizeField(alias, value)
+		}
+	}
+	return sanitized
+}
+
+// isXMLNamespaceAttr reports whether attr declares a namespace (xmlns or xmlns:prefix)
+func isXMLNamespaceAttr(name xml.Name) bool {
+	return name.Space == "xmlns" || (name.Space == "" && name.Local == "xmlns")
+}
+
+// xmlName formats a raw name with its namespace prefix
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// writeXMLEscaped writes text with XML special characters escaped. Attribute values
+// also escape quotes and whitespace that attribute normalization would otherwise lose.
+func writeXMLEscaped(out *bufio.Writer, text string, attr bool) {
+	last := 0
+	for i := 0; i < len(text); i++ {
+		var esc string
+		switch c := text[i]; {
+		case c == '&':
+			esc = "&amp;"
+		case c == '<':
+			esc = "&lt;"
+		case c == '>':
+			esc = "&gt;"
+		case attr && c == '"':
+			esc = "&quot;"
+		case attr && c == '\t':
+			esc = "&#x9;"
+		case attr && c == '\n':
+			esc = "&#xA;"
+		default:
+			continue
+		}
+		out.WriteString(text[last:i])
+		out.WriteString(esc)
+		last = i + 1
+	}
+	out.WriteString(text[last:])
+}
@@ -0,0 +1,219 @@
+package sanitizer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeXML_ISO20022(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "pain.001 debtor",
+			input: `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09">
+  <CstmrCdtTrfInitn>
+    <PmtInf>
+      <Dbtr>
+        <Nm>John Tan</Nm>
+        <PstlAdr>
+          <StrtNm>Orchard Road</StrtNm>
+          <PstCd>238801</PstCd>
+          <TwnNm>Singapore</TwnNm>
+          <Ctry>SG</Ctry>
+        </PstlAdr>
+      </Dbtr>
+      <DbtrAcct><Id><IBAN>AE070331234567890123456</IBAN></Id><Ccy>AED</Ccy></DbtrAcct>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-0001</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="SGD">1500.00</InstdAmt></Amt>
+        <RmtInf><Ustrd>Invoice 42 for Jane Lim</Ustrd></RmtInf>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>`,
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09">
+  <CstmrCdtTrfInitn>
+    <PmtInf>
+      <Dbtr>
+        <Nm>[REDACTED]</Nm>
+        <PstlAdr>
+          <StrtNm>[REDACTED]</StrtNm>
+          <PstCd>[REDACTED]</PstCd>
+          <TwnNm>[REDACTED]</TwnNm>
+          <Ctry>SG</Ctry>
+        </PstlAdr>
+      </Dbtr>
+      <DbtrAcct><Id><IBAN>[REDACTED]</IBAN></Id><Ccy>AED</Ccy></DbtrAcct>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-0001</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="SGD">1500.00</InstdAmt></Amt>
+        <RmtInf><Ustrd>[REDACTED]</Ustrd></RmtInf>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>`,
+		},
+		{
+			name: "pacs.008 with prefix",
+			input: `<ns:Document xmlns:ns="urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08">` +
+				`<ns:CdtTrfTxInf><ns:Cdtr><ns:Nm>Jane Lim</ns:Nm><ns:PstlAdr><ns:AdrLine>1 Raffles Place</ns:AdrLine></ns:PstlAdr>` +
+				`<ns:CtctDtls><ns:EmailAdr>jane@acme.com</ns:EmailAdr></ns:CtctDtls></ns:Cdtr>` +
+				`<ns:CdtrAgt><ns:FinInstnId><ns:BICFI>DBSSSGSGXXX</ns:BICFI></ns:FinInstnId></ns:CdtrAgt></ns:CdtTrfTxInf></ns:Document>`,
+			expected: `<ns:Document xmlns:ns="urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08">` +
+				`<ns:CdtTrfTxInf><ns:Cdtr><ns:Nm>[REDACTED]</ns:Nm><ns:PstlAdr><ns:AdrLine>[REDACTED]</ns:AdrLine></ns:PstlAdr>` +
+				`<ns:CtctDtls><ns:EmailAdr>[REDACTED]</ns:EmailAdr></ns:CtctDtls></ns:Cdtr>` +
+				`<ns:CdtrAgt><ns:FinInstnId><ns:BICFI>DBSSSGSGXXX</ns:BICFI></ns:FinInstnId></ns:CdtrAgt></ns:CdtTrfTxInf></ns:Document>`,
+		},
+		{
+			name: "camt.053 entry details",
+			input: `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"><BkToCstmrStmt><Stmt>` +
+				`<Ntry><Amt Ccy="SGD">250.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Sts><Cd>BOOK</Cd></Sts>` +
+				`<AddtlNtryInf>Refund to john@acme.com</AddtlNtryInf></Ntry></Stmt></BkToCstmrStmt></Document>`,
+			expected: `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"><BkToCstmrStmt><Stmt>` +
+				`<Ntry><Amt Ccy="SGD">250.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Sts><Cd>BOOK</Cd></Sts>` +
+				`<AddtlNtryInf>[REDACTED]</AddtlNtryInf></Ntry></Stmt></BkToCstmrStmt></Document>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := s.SanitizeXML(strings.NewReader(tt.input), &out); err != nil {
+				t.Fatalf("SanitizeXML() error = %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("got:\n%s\nwant:\n%s", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestSanitizeXML_SOAP(t *testing.T) {
+	s := NewDefault()
+
+	input := `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:wsse="http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-secext-1.0.xsd">
+  <soap:Header>
+    <wsse:Security>
+      <wsse:UsernameToken><wsse:Username>svc-payments</wsse:Username><wsse:Password Type="PasswordText">s3cr3t!</wsse:Password></wsse:UsernameToken>
+    </wsse:Security>
+  </soap:Header>
+  <soap:Body>
+    <!-- requested by john@acme.com -->
+    <GetCustomer xmlns="urn:bank" email="john@acme.com" channel="web"><CustomerId>C-100</CustomerId><Notes/></GetCustomer>
+  </soap:Body>
+</soap:Envelope>`
+
+	expected := `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:wsse="http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-secext-1.0.xsd">
+  <soap:Header>
+    <wsse:Security>
+      <wsse:UsernameToken><wsse:Username>[REDACTED]</wsse:Username><wsse:Password Type="PasswordText">[REDACTED]</wsse:Password></wsse:UsernameToken>
+    </wsse:Security>
+  </soap:Header>
+  <soap:Body>
+    <!-- requested by [REDACTED] -->
+    <GetCustomer xmlns="urn:bank" email="[REDACTED]" channel="web"><CustomerId>C-100</CustomerId><Notes/></GetCustomer>
+  </soap:Body>
+</soap:Envelope>`
+
+	var out bytes.Buffer
+	if err := s.SanitizeXML(strings.NewReader(input), &out); err != nil {
+		t.Fatalf("SanitizeXML() error = %v", err)
+	}
+	if out.String() != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), expected)
+	}
+}
+
+func TestSanitizeXML_Escaping(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"entities", `<a note="x &amp; &quot;y&quot;">1 &lt; 2 &amp; 3</a>`, `<a note="x &amp; &quot;y&quot;">1 &lt; 2 &amp; 3</a>`},
+		{"cdata", `<a><![CDATA[call +6591234567 <now>]]></a>`, `<a>[REDACTED]</a>`},
+		{"cdata safe", `<a><![CDATA[<b>]]></a>`, `<a>&lt;b&gt;</a>`},
+		{"empty element kept open", `<a></a>`, `<a></a>`},
+		{"doctype", `<!DOCTYPE a><a/>`, `<!DOCTYPE a><a/>`},
+		{"whitespace kept", "<Nm>\n  John Tan\n</Nm>", "<Nm>\n  [REDACTED]\n</Nm>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := s.SanitizeXML(strings.NewReader(tt.input), &out); err != nil {
+				t.Fatalf("SanitizeXML() error = %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("got %s, want %s", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestSanitizeXML_RemoveStrategy(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyRemove))
+
+	var out bytes.Buffer
+	input := `<Cdtr email="john@acme.com" type="retail"><Nm>Jane Lim</Nm></Cdtr>`
+	if err := s.SanitizeXML(strings.NewReader(input), &out); err != nil {
+		t.Fatalf("SanitizeXML() error = %v", err)
+	}
+	if expected := `<Cdtr type="retail"><Nm></Nm></Cdtr>`; out.String() != expected {
+		t.Errorf("got %s, want %s", out.String(), expected)
+	}
+}
+
+func TestSanitizeXML_ExplicitRules(t *testing.T) {
+	s := New(NewDefaultConfig().WithPreserve("Nm").WithRedact("EndToEndId"))
+
+	var out bytes.Buffer
+	input := `<Tx><Nm>ACME Trading Pte Ltd</Nm><EndToEndId>E2E-0001</EndToEndId></Tx>`
+	if err := s.SanitizeXML(strings.NewReader(input), &out); err != nil {
+		t.Fatalf("SanitizeXML() error = %v", err)
+	}
+	if expected := `<Tx><Nm>ACME Trading Pte Ltd</Nm><EndToEndId>[REDACTED]</EndToEndId></Tx>`; out.String() != expected {
+		t.Errorf("got %s, want %s", out.String(), expected)
+	}
+}
+
+func TestSanitizeXML_Malformed(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		name    string
+		input   string
+		written string
+	}{
+		{"mismatched end tag", `<a><Nm>John Tan</Nm></b>`, `<a><Nm>[REDACTED]</Nm>`},
+		{"unexpected end tag", `<a/></a>`, `<a/>`},
+		{"truncated", `<a><Nm>John Tan</Nm>`, `<a><Nm>[REDACTED]</Nm>`},
+		{"bad syntax", `<a><Nm>John Tan</Nm><`, `<a><Nm>[REDACTED]</Nm>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := s.SanitizeXML(strings.NewReader(tt.input), &out)
+			var syntaxErr *xml.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Expected *xml.SyntaxError, got %v", err)
+			}
+			if out.String() != tt.written {
+				t.Errorf("Expected output before the error to be flushed, got %s", out.String())
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package sanitizer
 
 import (
+	"reflect"
+	"time"
+
 	"go.uber.org/zap/zapcore"
 )
 
@@ -11,88 +14,149 @@ type ZapObject struct {
 	data      any
 }
 
-// MarshalLogObject implements zapcore.ObjectMarshaler
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+// Structs and maps are walked with reflection, so ints, uints, times and
+// durations are encoded as typed zap fields.
 func (z ZapObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
-	var m map[string]any
-
-	switch val := z.data.(type) {
-	case map[string]any:
-		m = z.sanitizer.SanitizeMap(val)
-	default:
-		m = z.sanitizer.SanitizeStruct(val)
+	value, _ := z.sanitizer.walkStructured("", z.data)
+	if obj, ok := value.(structuredObject); ok {
+		return marshalZapObject(enc, obj)
 	}
+	return nil
+}
 
-	return marshalMap(enc, m)
+// ZapArray wraps a slice or array for sanitization in zap logging
+// Implements zapcore.ArrayMarshaler
+type ZapArray struct {
+	sanitizer *Sanitizer
+	data      any
 }
 
-// marshalMap recursively marshals a map into the zap encoder
-func marshalMap(enc zapcore.ObjectEncoder, m map[string]any) error {
-	for k, v := range m {
-		if err := addField(enc, k, v); err != nil {
+// MarshalLogArray implements zapcore.ArrayMarshaler
+func (z ZapArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	value, _ := z.sanitizer.walkStructured("", z.data)
+	if arr, ok := value.(structuredArray); ok {
+		return marshalZapArray(enc, arr)
+	}
+	return nil
+}
+
+// marshalZapObject encodes a sanitized object into the zap encoder
+func marshalZapObject(enc zapcore.ObjectEncoder, obj structuredObject) error {
+	for _, f := range obj {
+		if err := addZapField(enc, f.Key, f.Value); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// addField adds a field to the encoder with appropriate type handling
-func addField(enc zapcore.ObjectEncoder, key string, value any) error {
+// addZapField adds a sanitized value to the encoder using its native zap type
+func addZapField(enc zapcore.ObjectEncoder, key string, value any) error {
 	switch val := value.(type) {
 	case string:
 		enc.AddString(key, val)
 	case int:
 		enc.AddInt(key, val)
+	case int8:
+		enc.AddInt8(key, val)
+	case int16:
+		enc.AddInt16(key, val)
+	case int32:
+		enc.AddInt32(key, val)
 	case int64:
 		enc.AddInt64(key, val)
+	case uint:
+		enc.AddUint(key, val)
+	case uint8:
+		enc.AddUint8(key, val)
+	case uint16:
+		enc.AddUint16(key, val)
+	case uint32:
+		enc.AddUint32(key, val)
+	case uint64:
+		enc.AddUint64(key, val)
+	case uintptr:
+		enc.AddUintptr(key, val)
+	case float32:
+		enc.AddFloat32(key, val)
 	case float64:
 		enc.AddFloat64(key, val)
 	case bool:
 		enc.AddBool(key, val)
-	case map[string]any:
+	case time.Time:
+		enc.AddTime(key, val)
+	case time.Duration:
+		enc.AddDuration(key, val)
+	case structuredObject:
 		return enc.AddObject(key, zapcore.ObjectMarshalerFunc(func(innerEnc zapcore.ObjectEncoder) error {
-			return marshalMap(innerEnc, val)
+			return marshalZapObject(innerEnc, val)
 		}))
-	case []any:
+	case structuredArray:
 		return enc.AddArray(key, zapcore.ArrayMarshalerFunc(func(arrEnc zapcore.ArrayEncoder) error {
-			return marshalSlice(arrEnc, val)
+			return marshalZapArray(arrEnc, val)
 		}))
-	case nil:
-		enc.AddReflected(key, nil)
 	default:
-		// For unknown types, use reflection
-		enc.AddReflected(key, val)
+		// nil and anything without a typed encoder
+		return enc.AddReflected(key, val)
 	}
 	return nil
 }
 
-// marshalSlice marshals a slice into the zap array encoder
-func marshalSlice(enc zapcore.ArrayEncoder, slice []any) error {
-	for _, v := range slice {
+// marshalZapArray encodes a sanitized array into the zap array encoder
+func marshalZapArray(enc zapcore.ArrayEncoder, arr structuredArray) error {
+	for _, v := range arr {
 		switch val := v.(type) {
 		case string:
 			enc.AppendString(val)
 		case int:
 			enc.AppendInt(val)
+		case int8:
+			enc.AppendInt8(val)
+		case int16:
+			enc.AppendInt16(val)
+		case int32:
+			enc.AppendInt32(val)
 		case int64:
 			enc.AppendInt64(val)
+		case uint:
+			enc.AppendUint(val)
+		case uint8:
+			enc.AppendUint8(val)
+		case uint16:
+			enc.AppendUint16(val)
+		case uint32:
+			enc.AppendUint32(val)
+		case uint64:
+			enc.AppendUint64(val)
+		case uintptr:
+			enc.AppendUintptr(val)
+		case float32:
+			enc.AppendFloat32(val)
 		case float64:
 			enc.AppendFloat64(val)
 		case bool:
 			enc.AppendBool(val)
-		case map[string]any:
+		case time.Time:
+			enc.AppendTime(val)
+		case time.Duration:
+			enc.AppendDuration(val)
+		case structuredObject:
 			if err := enc.AppendObject(zapcore.ObjectMarshalerFunc(func(objEnc zapcore.ObjectEncoder) error {
-				return marshalMap(objEnc, val)
+				return marshalZapObject(objEnc, val)
 			})); err != nil {
 				return err
 			}
-		case []any:
+		case structuredArray:
 			if err := enc.AppendArray(zapcore.ArrayMarshalerFunc(func(arrEnc zapcore.ArrayEncoder) error {
-				return marshalSlice(arrEnc, val)
+				return marshalZapArray(arrEnc, val)
 			})); err != nil {
 				return err
 			}
 		default:
-			enc.AppendReflected(val)
+			if err := enc.AppendReflected(val); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -103,6 +167,11 @@ func (s *Sanitizer) ZapObject(value any) ZapObject {
 	return ZapObject{sanitizer: s, data: value}
 }
 
+// ZapArray creates a ZapArray for use in zap logging
+func (s *Sanitizer) ZapArray(value any) ZapArray {
+	return ZapArray{sanitizer: s, data: value}
+}
+
 // ZapString sanitizes a string field for zap
 func (s *Sanitizer) ZapString(key, value string) zapcore.Field {
 	sanitized := s.SanitizeField(key, value)
@@ -113,8 +182,20 @@ func (s *Sanitizer) ZapString(key, value string) zapcore.Field {
 	}
 }
 
-// ZapField creates a sanitized zap field from any value
+// ZapField creates a sanitized zap field from any value.
+// Slices and arrays become array fields; everything else is an object field.
 func (s *Sanitizer) ZapField(key string, value any) zapcore.Field {
+	if value != nil {
+		kind := reflect.TypeOf(value).Kind()
+		if (kind == reflect.Slice || kind == reflect.Array) && reflect.TypeOf(value).Elem().Kind() != reflect.Uint8 {
+			return zapcore.Field{
+				Key:       key,
+				Type:      zapcore.ArrayMarshalerType,
+				Interface: s.ZapArray(value),
+			}
+		}
+	}
+
 	return zapcore.Field{
 		Key:       key,
 		Type:      zapcore.ObjectMarshalerType,
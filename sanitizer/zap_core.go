@@ -0,0 +1,308 @@
+package sanitizer
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ZapCore is a zapcore.Core that sanitizes every field before delegating to the wrapped core
+type ZapCore struct {
+	inner           zapcore.Core
+	sanitizer       *Sanitizer
+	sanitizeMessage bool
+}
+
+// WrapZapCore wraps core so that every field passed to Write and With is sanitized.
+// String, byte string, error, Stringer, reflected and object/array marshaler fields
+// are sanitized using the field key for name matching.
+//
+// Example:
+//
+//	s := NewDefault()
+//	logger := zap.New(WrapZapCore(core, s))
+//
+//	// Or cover an existing logger without code changes:
+//	logger = logger.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+//		return WrapZapCore(c, s)
+//	}))
+func WrapZapCore(core zapcore.Core, s *Sanitizer) *ZapCore {
+	return &ZapCore{inner: core, sanitizer: s}
+}
+
+// WithMessageSanitization returns a copy of the core that also sanitizes the log
+// message text, replacing only the PII inside it
+func (c *ZapCore) WithMessageSanitization(enabled bool) *ZapCore {
+	clone := *c
+	clone.sanitizeMessage = enabled
+	return &clone
+}
+
+// ZapCoreOption returns a zap.Option that installs the sanitizing core on a logger
+func (s *Sanitizer) ZapCoreOption() zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return WrapZapCore(core, s)
+	})
+}
+
+// Enabled implements zapcore.Core
+func (c *ZapCore) Enabled(level zapcore.Level) bool {
+	return c.inner.Enabled(level)
+}
+
+// With implements zapcore.Core
+func (c *ZapCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.inner = c.inner.With(c.sanitizer.sanitizeZapFields(fields))
+	return &clone
+}
+
+// Check implements zapcore.Core.
+// The wrapped core decides whether and where to log, preserving sampling, level rules
+// and the choice of each core in a tee. The entry it checked is written with
+// sanitized fields, so only the cores that accepted it see it.
+func (c *ZapCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	checked := c.inner.Check(ent, nil)
+	if checked == nil {
+		return ce
+	}
+	writer := &zapCheckedWriter{ZapCore: c, checked: checked}
+	ce = ce.AddCore(ent, writer)
+	writer.outer = ce
+	return ce
+}
+
+// Write implements zapcore.Core
+func (c *ZapCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.inner.Write(c.sanitizeEntry(ent), c.sanitizer.sanitizeZapFields(fields))
+}
+
+// sanitizeEntry sanitizes the message of ent if message sanitization is enabled
+func (c *ZapCore) sanitizeEntry(ent zapcore.Entry) zapcore.Entry {
+	if c.sanitizeMessage {
+		ent.Message = c.sanitizer.sanitizeText(ent.Message)
+	}
+	return ent
+}
+
+// Sync implements zapcore.Core
+func (c *ZapCore) Sync() error {
+	return c.inner.Sync()
+}
+
+// zapCheckedWriter writes one entry checked by the wrapped core. It is registered in
+// place of the cores that accepted the entry and writes to them with sanitized fields.
+type zapCheckedWriter struct {
+	*ZapCore
+	checked *zapcore.CheckedEntry // the entry as checked by the wrapped core
+	outer   *zapcore.CheckedEntry // the entry this writer is registered with
+}
+
+// Write implements zapcore.Core. Write errors are reported to the error output of
+// the logger, as zap does for the cores it writes to.
+func (w *zapCheckedWriter) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	w.checked.Entry = w.sanitizeEntry(ent)
+	w.checked.ErrorOutput = w.outer.ErrorOutput
+	w.checked.Write(w.sanitizer.sanitizeZapFields(fields)...)
+	return nil
+}
+
+// sanitizeZapFields returns a sanitized copy of fields
+func (s *Sanitizer) sanitizeZapFields(fields []zapcore.Field) []zapcore.Field {
+	result := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		result[i] = s.sanitizeZapField(f)
+	}
+	return result
+}
+
+// sanitizeZapField sanitizes a single zap field using its key for field name matching
+func (s *Sanitizer) sanitizeZapField(f zapcore.Field) zapcore.Field {
+	switch f.Type {
+	case zapcore.StringType:
+		return s.zapStringField(f.Key, f.String)
+
+	case zapcore.ByteStringType:
+		if b, ok := f.Interface.([]byte); ok {
+			sanitized := s.SanitizeField(f.Key, string(b))
+			if s.isRemoved(string(b), sanitized) {
+				return zap.Skip()
+			}
+			return zap.ByteString(f.Key, []byte(sanitized))
+		}
+
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok {
+			// The wrapper drops zap's errorVerbose output, which may include stack arguments
+			sanitized, keep := s.sanitizeError(f.Key, err)
+			if !keep {
+				return zap.Skip()
+			}
+			return zap.NamedError(f.Key, sanitized)
+		}
+
+	case zapcore.StringerType:
+		if str, ok := f.Interface.(fmt.Stringer); ok {
+			return s.zapStringField(f.Key, safeStringer(str))
+		}
+
+	case zapcore.ReflectType:
+		value, keep := s.walkStructured(f.Key, f.Interface)
+		if !keep {
+			return zap.Skip()
+		}
+		return zapStructuredField(f.Key, value)
+
+	case zapcore.ObjectMarshalerType, zapcore.InlineMarshalerType:
+		// Values wrapped with ZapObject are already sanitized
+		if _, ok := f.Interface.(ZapObject); ok {
+			return f
+		}
+		if m, ok := f.Interface.(zapcore.ObjectMarshaler); ok {
+			f.Interface = sanitizingObjectMarshaler{sanitizer: s, marshaler: m}
+		}
+
+	case zapcore.ArrayMarshalerType:
+		if _, ok := f.Interface.(ZapArray); ok {
+			return f
+		}
+		if m, ok := f.Interface.(zapcore.ArrayMarshaler); ok {
+			f.Interface = sanitizingArrayMarshaler{sanitizer: s, marshaler: m}
+		}
+	}
+
+	// Numeric, bool, time, duration and namespace fields carry no string content
+	return f
+}
+
+// zapStringField builds a sanitized string field, skipping it under StrategyRemove
+func (s *Sanitizer) zapStringField(key, value string) zapcore.Field {
+	sanitized := s.SanitizeField(key, value)
+	if s.isRemoved(value, sanitized) {
+		return zap.Skip()
+	}
+	return zap.String(key, sanitized)
+}
+
+// isRemoved reports whether a value was redacted under StrategyRemove
+func (s *Sanitizer) isRemoved(original, sanitized string) bool {
+	return s.config.Strategy == StrategyRemove && sanitized == "" && original != ""
+}
+
+// zapStructuredField builds a field for a sanitized value using its native zap type
+func zapStructuredField(key string, value any) zapcore.Field {
+	switch val := value.(type) {
+	case structuredObject:
+		return zap.Object(key, zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			return marshalZapObject(enc, val)
+		}))
+	case structuredArray:
+		return zap.Array(key, zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+			return marshalZapArray(enc, val)
+		}))
+	default:
+		return zap.Any(key, val)
+	}
+}
+
+// safeStringer calls String, recovering from panics the way zap's encoder does
+func safeStringer(str fmt.Stringer) (result string) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = fmt.Sprintf("<PANIC=%v>", r)
+		}
+	}()
+	return str.String()
+}
+
+// sanitizingObjectMarshaler sanitizes everything a wrapped ObjectMarshaler encodes
+type sanitizingObjectMarshaler struct {
+	sanitizer *Sanitizer
+	marshaler zapcore.ObjectMarshaler
+}
+
+func (m sanitizingObjectMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return m.marshaler.MarshalLogObject(&sanitizingObjectEncoder{ObjectEncoder: enc, sanitizer: m.sanitizer})
+}
+
+// sanitizingArrayMarshaler sanitizes everything a wrapped ArrayMarshaler encodes
+type sanitizingArrayMarshaler struct {
+	sanitizer *Sanitizer
+	marshaler zapcore.ArrayMarshaler
+}
+
+func (m sanitizingArrayMarshaler) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	return m.marshaler.MarshalLogArray(&sanitizingArrayEncoder{ArrayEncoder: enc, sanitizer: m.sanitizer})
+}
+
+// sanitizingObjectEncoder intercepts string-carrying ObjectEncoder methods.
+// Methods for numeric and time values are forwarded unchanged via embedding.
+type sanitizingObjectEncoder struct {
+	zapcore.ObjectEncoder
+	sanitizer *Sanitizer
+}
+
+func (e *sanitizingObjectEncoder) AddString(key, value string) {
+	sanitized := e.sanitizer.SanitizeField(key, value)
+	if e.sanitizer.isRemoved(value, sanitized) {
+		return
+	}
+	e.ObjectEncoder.AddString(key, sanitized)
+}
+
+func (e *sanitizingObjectEncoder) AddByteString(key string, value []byte) {
+	sanitized := e.sanitizer.SanitizeField(key, string(value))
+	if e.sanitizer.isRemoved(string(value), sanitized) {
+		return
+	}
+	e.ObjectEncoder.AddByteString(key, []byte(sanitized))
+}
+
+func (e *sanitizingObjectEncoder) AddObject(key string, marshaler zapcore.ObjectMarshaler) error {
+	return e.ObjectEncoder.AddObject(key, sanitizingObjectMarshaler{sanitizer: e.sanitizer, marshaler: marshaler})
+}
+
+func (e *sanitizingObjectEncoder) AddArray(key string, marshaler zapcore.ArrayMarshaler) error {
+	return e.ObjectEncoder.AddArray(key, sanitizingArrayMarshaler{sanitizer: e.sanitizer, marshaler: marshaler})
+}
+
+func (e *sanitizingObjectEncoder) AddReflected(key string, value any) error {
+	sanitized, keep := e.sanitizer.walkStructured(key, value)
+	if !keep {
+		return nil
+	}
+	return addZapField(e.ObjectEncoder, key, sanitized)
+}
+
+// sanitizingArrayEncoder intercepts string-carrying ArrayEncoder methods.
+// Array elements have no field name, so strings are checked by content only.
+type sanitizingArrayEncoder struct {
+	zapcore.ArrayEncoder
+	sanitizer *Sanitizer
+}
+
+func (e *sanitizingArrayEncoder) AppendString(value string) {
+	e.ArrayEncoder.AppendString(e.sanitizer.sanitizeContent(value))
+}
+
+func (e *sanitizingArrayEncoder) AppendByteString(value []byte) {
+	e.ArrayEncoder.AppendByteString([]byte(e.sanitizer.sanitizeContent(string(value))))
+}
+
+func (e *sanitizingArrayEncoder) AppendObject(marshaler zapcore.ObjectMarshaler) error {
+	return e.ArrayEncoder.AppendObject(sanitizingObjectMarshaler{sanitizer: e.sanitizer, marshaler: marshaler})
+}
+
+func (e *sanitizingArrayEncoder) AppendArray(marshaler zapcore.ArrayMarshaler) error {
+	return e.ArrayEncoder.AppendArray(sanitizingArrayMarshaler{sanitizer: e.sanitizer, marshaler: marshaler})
+}
+
+func (e *sanitizingArrayEncoder) AppendReflected(value any) error {
+	sanitized, keep := e.sanitizer.walkStructured("", value)
+	if !keep {
+		// Removed strings stay as empty elements, like walkArray
+		sanitized = ""
+	}
+	return marshalZapArray(e.ArrayEncoder, structuredArray{sanitized})
+}
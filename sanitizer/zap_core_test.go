@@ -0,0 +1,259 @@
+package sanitizer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// emailStringer is a fmt.Stringer that exposes an email
+type emailStringer struct{ email string }
+
+func (t emailStringer) String() string { return "contact " + t.email }
+
+// testUser is a zapcore.ObjectMarshaler that logs PII without sanitizing it
+type testUser struct {
+	Email   string
+	OrderID string
+	Tags    []string
+}
+
+func (u testUser) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("email", u.Email)
+	enc.AddString("orderId", u.OrderID)
+	return enc.AddArray("tags", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+		for _, tag := range u.Tags {
+			arr.AppendString(tag)
+		}
+		return nil
+	}))
+}
+
+func TestWrapZapCore_FieldTypes(t *testing.T) {
+	s := NewDefault()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(WrapZapCore(core, s))
+
+	type profile struct {
+		Phone   string `json:"phone"`
+		OrderID string `json:"orderId"`
+	}
+
+	logger.Info("user action",
+		zap.String("email", "user@example.com"),
+		zap.String("orderId", "ORD-123"),
+		zap.ByteString("fullName", []byte("John Doe")),
+		zap.Error(errors.New("lookup failed for user@example.com")),
+		zap.Stringer("contact", emailStringer{email: "user@example.com"}),
+		zap.Reflect("profile", profile{Phone: "+6591234567", OrderID: "ORD-1"}),
+		zap.Object("user", testUser{Email: "user@example.com", OrderID: "ORD-2", Tags: []string{"vip", "user@example.com"}}),
+		zap.Strings("emails", []string{"user@example.com", "ORD-3"}),
+		zap.Int("count", 3),
+	)
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 log entry, got %d", len(entries))
+	}
+
+	ctx := entries[0].ContextMap()
+	if ctx["email"] != "[REDACTED]" {
+		t.Errorf("Expected email to be redacted, got %v", ctx["email"])
+	}
+	if ctx["orderId"] != "ORD-123" {
+		t.Errorf("Expected orderId to be preserved, got %v", ctx["orderId"])
+	}
+	if ctx["fullName"] != "[REDACTED]" {
+		t.Errorf("Expected byte string name to be redacted, got %v", ctx["fullName"])
+	}
+	if ctx["error"] != "lookup failed for [REDACTED]" {
+		t.Errorf("Expected email in error to be redacted, got %v", ctx["error"])
+	}
+	if ctx["contact"] != "[REDACTED]" {
+		t.Errorf("Expected Stringer to be redacted, got %v", ctx["contact"])
+	}
+	if ctx["count"] != int64(3) {
+		t.Errorf("Expected count to be preserved as int64, got %#v", ctx["count"])
+	}
+
+	profileMap := ctx["profile"].(map[string]any)
+	if profileMap["phone"] != "[REDACTED]" || profileMap["orderId"] != "ORD-1" {
+		t.Errorf("Expected reflected profile to be sanitized, got %v", profileMap)
+	}
+
+	userMap := ctx["user"].(map[string]any)
+	if userMap["email"] != "[REDACTED]" || userMap["orderId"] != "ORD-2" {
+		t.Errorf("Expected object marshaler to be sanitized, got %v", userMap)
+	}
+	tags := userMap["tags"].([]any)
+	if tags[0] != "vip" || tags[1] != "[REDACTED]" {
+		t.Errorf("Expected nested array to be sanitized, got %v", tags)
+	}
+
+	emails := ctx["emails"].([]any)
+	if emails[0] != "[REDACTED]" || emails[1] != "ORD-3" {
+		t.Errorf("Expected string array to be sanitized, got %v", emails)
+	}
+}
+
+func TestWrapZapCore_With(t *testing.T) {
+	s := NewDefault()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(WrapZapCore(core, s)).With(zap.String("email", "user@example.com"))
+
+	logger.Info("test", zap.String("orderId", "ORD-123"))
+
+	ctx := logs.All()[0].ContextMap()
+	if ctx["email"] != "[REDACTED]" {
+		t.Errorf("Expected With field to be redacted, got %v", ctx["email"])
+	}
+	if ctx["orderId"] != "ORD-123" {
+		t.Errorf("Expected orderId to be preserved, got %v", ctx["orderId"])
+	}
+}
+
+func TestZapCoreOption_ExistingLogger(t *testing.T) {
+	s := NewDefault()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core).WithOptions(s.ZapCoreOption())
+
+	logger.Info("login", zap.String("phone", "+6591234567"))
+	logger.Debug("ignored", zap.String("email", "user@example.com"))
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected level filtering of the wrapped core to apply, got %d entries", len(entries))
+	}
+	if entries[0].ContextMap()["phone"] != "[REDACTED]" {
+		t.Errorf("Expected phone to be redacted, got %v", entries[0].ContextMap()["phone"])
+	}
+}
+
+func TestWrapZapCore_ReflectedTypes(t *testing.T) {
+	s := NewDefault()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(WrapZapCore(core, s))
+
+	type account struct {
+		ID    int64  `json:"id"`
+		Email string `json:"email"`
+	}
+	logger.Info("test",
+		zap.Reflect("n", int64(1<<60+1)),
+		zap.Reflect("account", account{ID: 1<<60 + 1, Email: "user@example.com"}),
+		zap.Object("user", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			return enc.AddReflected("account", account{ID: 1<<60 + 1, Email: "user@example.com"})
+		})),
+	)
+
+	ctx := logs.All()[0].ContextMap()
+	if ctx["n"] != int64(1<<60+1) {
+		t.Errorf("Expected int64 to keep its value, got %v (%T)", ctx["n"], ctx["n"])
+	}
+	want := map[string]any{"id": int64(1<<60 + 1), "email": "[REDACTED]"}
+	if got := ctx["account"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected reflected struct %v, got %v", want, got)
+	}
+	if got := ctx["user"].(map[string]any)["account"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected AddReflected struct %v, got %v", want, got)
+	}
+}
+
+func TestWrapZapCore_Tee(t *testing.T) {
+	s := NewDefault()
+
+	debugCore, debugLogs := observer.New(zapcore.DebugLevel)
+	errorCore, errorLogs := observer.New(zapcore.ErrorLevel)
+	logger := zap.New(WrapZapCore(zapcore.NewTee(debugCore, errorCore), s))
+
+	logger.Debug("lookup", zap.String("email", "user@example.com"))
+	logger.Error("failed", zap.String("email", "user@example.com"))
+
+	if n := debugLogs.Len(); n != 2 {
+		t.Errorf("Expected the debug core to log 2 entries, got %d", n)
+	}
+	entries := errorLogs.All()
+	if len(entries) != 1 || entries[0].Message != "failed" {
+		t.Fatalf("Expected the error core to log only the error entry, got %v", entries)
+	}
+	if entries[0].ContextMap()["email"] != "[REDACTED]" {
+		t.Errorf("Expected email to be redacted, got %v", entries[0].ContextMap()["email"])
+	}
+}
+
+func TestWrapZapCore_MessageSanitization(t *testing.T) {
+	s := NewDefault()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(WrapZapCore(core, s))
+	logger.Info("sent OTP to +6591234567")
+	if msg := logs.All()[0].Message; msg != "sent OTP to +6591234567" {
+		t.Errorf("Expected message to be untouched by default, got %q", msg)
+	}
+
+	core, logs = observer.New(zapcore.InfoLevel)
+	logger = zap.New(WrapZapCore(core, s).WithMessageSanitization(true)).With(zap.String("orderId", "ORD-1"))
+	logger.Info("sent OTP to +6591234567")
+	if msg := logs.All()[0].Message; msg != "sent OTP to [REDACTED]" {
+		t.Errorf("Expected PII in message to be replaced in place, got %q", msg)
+	}
+}
+
+func TestWrapZapCore_ZapObjectNotSanitizedTwice(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyHash))
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(WrapZapCore(core, s))
+
+	logger.Info("test", s.ZapField("user", map[string]any{"email": "user@example.com"}))
+
+	userMap := logs.All()[0].ContextMap()["user"].(map[string]any)
+	if userMap["email"] != s.SanitizeField("email", "user@example.com") {
+		t.Errorf("Expected single hash, got %v", userMap["email"])
+	}
+}
+
+func TestWrapZapCore_RemoveStrategy(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyRemove))
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(WrapZapCore(core, s))
+
+	logger.Info("test", zap.String("email", "user@example.com"), zap.String("orderId", "ORD-1"))
+
+	ctx := logs.All()[0].ContextMap()
+	if _, exists := ctx["email"]; exists {
+		t.Errorf("Expected email field to be removed, got %v", ctx)
+	}
+	if ctx["orderId"] != "ORD-1" {
+		t.Errorf("Expected orderId to be preserved, got %v", ctx["orderId"])
+	}
+}
+
+// panicStringer panics when formatted
+type panicStringer struct{}
+
+func (panicStringer) String() string { panic("boom") }
+
+func TestWrapZapCore_PanickingStringer(t *testing.T) {
+	s := NewDefault()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(WrapZapCore(core, s))
+
+	logger.Info("test", zap.Stringer("value", panicStringer{}))
+
+	value, _ := logs.All()[0].ContextMap()["value"].(string)
+	if !strings.Contains(value, "PANIC") {
+		t.Errorf("Expected panic to be recovered, got %q", value)
+	}
+}
@@ -1,6 +1,8 @@
 package sanitizer
 
 import (
+	"time"
+
 	"github.com/rs/zerolog"
 )
 
@@ -11,86 +13,117 @@ type ZerologObject struct {
 	data      any
 }
 
-// MarshalZerologObject implements zerolog.LogObjectMarshaler
+// MarshalZerologObject implements zerolog.LogObjectMarshaler.
+// Structs and maps are walked with reflection, so ints, times and durations
+// are written with zerolog's typed methods instead of as JSON round-tripped values.
 func (z ZerologObject) MarshalZerologObject(e *zerolog.Event) {
-	var m map[string]any
-
-	switch val := z.data.(type) {
-	case map[string]any:
-		m = z.sanitizer.SanitizeMap(val)
-	default:
-		m = z.sanitizer.SanitizeStruct(val)
+	value, _ := z.sanitizer.walkStructured("", z.data)
+	if obj, ok := value.(structuredObject); ok {
+		marshalZerologObject(e, obj)
 	}
-
-	marshalZerologMap(e, m)
 }
 
-// marshalZerologMap marshals a map into zerolog event
-func marshalZerologMap(e *zerolog.Event, m map[string]any) {
-	for k, v := range m {
-		addZerologField(e, k, v)
+// marshalZerologObject writes sanitized fields to a zerolog event in order
+func marshalZerologObject(e *zerolog.Event, obj structuredObject) {
+	for _, f := range obj {
+		addZerologField(e, f.Key, f.Value)
 	}
 }
 
-// addZerologField adds a field to the event with appropriate type handling
+// addZerologField adds a sanitized value to the event using its native zerolog type
 func addZerologField(e *zerolog.Event, key string, value any) {
 	switch val := value.(type) {
 	case string:
 		e.Str(key, val)
 	case int:
 		e.Int(key, val)
+	case int8:
+		e.Int8(key, val)
+	case int16:
+		e.Int16(key, val)
+	case int32:
+		e.Int32(key, val)
 	case int64:
 		e.Int64(key, val)
+	case uint:
+		e.Uint(key, val)
+	case uint8:
+		e.Uint8(key, val)
+	case uint16:
+		e.Uint16(key, val)
+	case uint32:
+		e.Uint32(key, val)
+	case uint64:
+		e.Uint64(key, val)
+	case float32:
+		e.Float32(key, val)
 	case float64:
 		e.Float64(key, val)
 	case bool:
 		e.Bool(key, val)
-	case map[string]any:
-		e.Object(key, zerologMapMarshaler{m: val})
-	case []any:
-		e.Array(key, zerologSliceMarshaler{slice: val})
-	case nil:
-		e.Interface(key, nil)
+	case time.Time:
+		e.Time(key, val)
+	case time.Duration:
+		e.Dur(key, val)
+	case structuredObject:
+		e.Object(key, zerologObjectMarshaler(val))
+	case structuredArray:
+		e.Array(key, zerologArrayMarshaler(val))
 	default:
-		// For unknown types, use interface
 		e.Interface(key, val)
 	}
 }
 
-// zerologMapMarshaler wraps a map for zerolog object marshaling
-type zerologMapMarshaler struct {
-	m map[string]any
-}
+// zerologObjectMarshaler writes a sanitized object as a nested zerolog dict
+type zerologObjectMarshaler structuredObject
 
-func (zm zerologMapMarshaler) MarshalZerologObject(e *zerolog.Event) {
-	marshalZerologMap(e, zm.m)
+func (o zerologObjectMarshaler) MarshalZerologObject(e *zerolog.Event) {
+	marshalZerologObject(e, structuredObject(o))
 }
 
-// zerologSliceMarshaler wraps a slice for zerolog array marshaling
-type zerologSliceMarshaler struct {
-	slice []any
-}
+// zerologArrayMarshaler writes a sanitized array as a zerolog array
+type zerologArrayMarshaler structuredArray
 
-func (zs zerologSliceMarshaler) MarshalZerologArray(a *zerolog.Array) {
-	for _, v := range zs.slice {
+func (arr zerologArrayMarshaler) MarshalZerologArray(a *zerolog.Array) {
+	for _, v := range arr {
 		switch val := v.(type) {
 		case string:
 			a.Str(val)
 		case int:
 			a.Int(val)
+		case int8:
+			a.Int8(val)
+		case int16:
+			a.Int16(val)
+		case int32:
+			a.Int32(val)
 		case int64:
 			a.Int64(val)
+		case uint:
+			a.Uint(val)
+		case uint8:
+			a.Uint8(val)
+		case uint16:
+			a.Uint16(val)
+		case uint32:
+			a.Uint32(val)
+		case uint64:
+			a.Uint64(val)
+		case float32:
+			a.Float32(val)
 		case float64:
 			a.Float64(val)
 		case bool:
 			a.Bool(val)
-		case map[string]any:
-			a.Object(zerologMapMarshaler{m: val})
-		case []any:
-			// Nested arrays - use interface for simplicity
-			a.Interface(val)
+		case time.Time:
+			a.Time(val)
+		case time.Duration:
+			a.Dur(val)
+		case structuredObject:
+			a.Object(zerologObjectMarshaler(val))
 		default:
-			a.Interface(val)
+			// zerolog arrays cannot nest arrays directly
+			a.Interface(structuredToPlain(val))
 		}
 	}
 }
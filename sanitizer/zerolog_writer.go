@@ -0,0 +1,95 @@
+package sanitizer
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/rs/zerolog"
+)
+
+// zerologWriter sanitizes zerolog JSON events before writing them to the wrapped writer
+type zerologWriter struct {
+	out       io.Writer
+	sanitizer *Sanitizer
+}
+
+// ZerologWriter returns an io.Writer for zerolog.New that sanitizes every emitted event.
+// Each JSON line is sanitized with the same rules as SanitizeMap while keeping zerolog's
+// field order; the level, time, caller and message fields are copied unchanged
+// (add ZerologHook to sanitize the message). Lines that are not JSON objects, such as
+// console writer output, are sanitized as free text with field name rules applied to
+// their key=value tokens.
+//
+// Example:
+//
+//	s := NewDefault()
+//	logger := zerolog.New(ZerologWriter(os.Stdout, s)).With().Timestamp().Logger()
+//	logger.Info().Str("email", "user@example.com").Msg("login") // "email":"[REDACTED]"
+func ZerologWriter(w io.Writer, s *Sanitizer) io.Writer {
+	return &zerologWriter{out: w, sanitizer: s}
+}
+
+// Write implements io.Writer. zerolog writes one complete event per call.
+func (w *zerologWriter) Write(p []byte) (int, error) {
+	opts := jsonOrderedOptions{
+		preserve: map[string]bool{
+			zerolog.LevelFieldName:     true,
+			zerolog.TimestampFieldName: true,
+			zerolog.CallerFieldName:    true,
+			zerolog.MessageFieldName:   true,
+		},
+		// ZerologHook writes a sanitized message ahead of zerolog's original one
+		dedupe: map[string]bool{zerolog.MessageFieldName: true},
+		// Error messages stay readable with only their PII redacted
+		text: map[string]bool{zerolog.ErrorFieldName: true},
+	}
+
+	var out bytes.Buffer
+	for _, line := range bytes.SplitAfter(p, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		body := bytes.TrimRight(line, "\r\n")
+		sanitized, err := w.sanitizer.sanitizeJSONOrdered(body, opts)
+		if err != nil {
+			// Console writer output and partial writes are sanitized as free text
+			sanitized = []byte(w.sanitizer.sanitizeTextFields(string(body)))
+		}
+		out.Write(sanitized)
+		out.Write(line[len(body):])
+	}
+
+	if _, err := w.out.Write(out.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// zerologHook sanitizes event messages
+type zerologHook struct {
+	sanitizer *Sanitizer
+}
+
+// ZerologHook returns a zerolog.Hook that sanitizes the event message, replacing
+// only the PII inside it. Message sanitization is opt-in per logger: loggers
+// without the hook keep their messages unchanged.
+//
+// zerolog hooks cannot replace the message, and zerolog appends the original
+// message after hooks run. When the message contains PII the hook therefore writes
+// the sanitized message first, and ZerologWriter keeps only that first occurrence.
+// Use the hook together with ZerologWriter:
+//
+//	logger := zerolog.New(ZerologWriter(os.Stdout, s)).Hook(ZerologHook(s))
+func ZerologHook(s *Sanitizer) zerolog.Hook {
+	return zerologHook{sanitizer: s}
+}
+
+// Run implements zerolog.Hook
+func (h zerologHook) Run(e *zerolog.Event, _ zerolog.Level, msg string) {
+	if msg == "" {
+		return
+	}
+	if sanitized := h.sanitizer.sanitizeText(msg); sanitized != msg {
+		e.Str(zerolog.MessageFieldName, sanitized)
+	}
+}
@@ -0,0 +1,171 @@
+package sanitizer
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestZerologWriter_SanitizesFields(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := zerolog.New(ZerologWriter(&buf, s))
+
+	logger.Info().
+		Str("email", "user@example.com").
+		Str("orderId", "ORD-123").
+		Int("count", 3).
+		Float64("amount", 100.50).
+		Dict("customer", zerolog.Dict().
+			Str("fullName", "John Doe").
+			Str("tier", "gold")).
+		Strs("contacts", []string{"+6591234567", "ORD-9"}).
+		Err(errors.New("lookup failed for user@example.com")).
+		Msg("user action")
+
+	expected := `{"level":"info","email":"[REDACTED]","orderId":"ORD-123","count":3,"amount":100.5,` +
+		`"customer":{"fullName":"[REDACTED]","tier":"gold"},"contacts":["[REDACTED]","ORD-9"],` +
+		`"error":"lookup failed for [REDACTED]","message":"user action"}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected output:\n got: %s\nwant: %s", buf.String(), expected)
+	}
+}
+
+func TestZerologWriter_PreservesLevelAndTime(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := zerolog.New(ZerologWriter(&buf, s)).With().Timestamp().Logger()
+
+	logger.Warn().Str("phone", "+6591234567").Msg("otp sent")
+
+	output := buf.String()
+	if !strings.HasPrefix(output, `{"level":"warn","phone":"[REDACTED]","time":"`) {
+		t.Errorf("Expected zerolog field order with level and time unchanged, got: %s", output)
+	}
+	if strings.Contains(output, "+6591234567") {
+		t.Errorf("Expected phone to be redacted, got: %s", output)
+	}
+}
+
+func TestZerologWriter_ContextFields(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := zerolog.New(ZerologWriter(&buf, s)).With().Str("email", "user@example.com").Logger()
+
+	logger.Info().Msg("test")
+
+	if strings.Contains(buf.String(), "user@example.com") {
+		t.Errorf("Expected context field to be redacted, got: %s", buf.String())
+	}
+}
+
+func TestZerologWriter_NonJSON(t *testing.T) {
+	s := NewDefault()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain text", "plain text line\n", "plain text line\n"},
+		{"console writer", "10:30AM INF login email=user@example.com orderId=ORD-1\n", "10:30AM INF login email=[REDACTED] orderId=ORD-1\n"},
+		{"partial json", `{"level":"info","contact":"user@example.com`, `{"level":"info","contact":"[REDACTED]`},
+		{"partial write", "password=hunter2 contact user@example.com", "password=[REDACTED] contact [REDACTED]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := ZerologWriter(&buf, s)
+
+			n, err := w.Write([]byte(tt.input))
+			if err != nil || n != len(tt.input) {
+				t.Fatalf("Write() = %d, %v", n, err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestZerologWriter_RemoveStrategy(t *testing.T) {
+	s := New(NewDefaultConfig().WithStrategy(StrategyRemove))
+
+	var buf bytes.Buffer
+	logger := zerolog.New(ZerologWriter(&buf, s))
+
+	logger.Info().Str("email", "user@example.com").Str("orderId", "ORD-1").Msg("")
+
+	expected := `{"level":"info","orderId":"ORD-1"}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected email to be removed, got: %s", buf.String())
+	}
+}
+
+func TestZerologHook_SanitizesMessage(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := zerolog.New(ZerologWriter(&buf, s)).Hook(ZerologHook(s))
+
+	logger.Info().Str("orderId", "ORD-1").Msg("sent receipt to user@example.com")
+
+	output := buf.String()
+	if !strings.Contains(output, `"message":"sent receipt to [REDACTED]"`) {
+		t.Errorf("Expected PII in message to be replaced in place, got: %s", output)
+	}
+	if strings.Count(output, `"message"`) != 1 {
+		t.Errorf("Expected a single message field, got: %s", output)
+	}
+
+	buf.Reset()
+	logger.Info().Msg("order shipped")
+	if !strings.Contains(buf.String(), `"message":"order shipped"`) {
+		t.Errorf("Expected clean message to be untouched, got: %s", buf.String())
+	}
+}
+
+func TestZerologWriter_MessageUntouchedWithoutHook(t *testing.T) {
+	s := NewDefault()
+
+	var buf bytes.Buffer
+	logger := zerolog.New(ZerologWriter(&buf, s))
+
+	logger.Info().Msg("sent receipt to user@example.com")
+
+	if !strings.Contains(buf.String(), "user@example.com") {
+		t.Errorf("Expected message to be left to ZerologHook, got: %s", buf.String())
+	}
+}
+
+func TestSanitizeJSONOrdered_Errors(t *testing.T) {
+	s := NewDefault()
+
+	for _, input := range []string{`[1,2]`, `{"a":1}{"b":2}`, `{"a":`, `not json`} {
+		if _, err := s.sanitizeJSONOrdered([]byte(input), jsonOrderedOptions{}); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestSanitizeJSONOrdered_PreservesFormatting(t *testing.T) {
+	s := NewDefault()
+
+	input := `{"z":1.50,"a":"<b>&","nested":{"email":"user@example.com","list":[{"phone":"+6591234567"},null,true]}}`
+	expected := `{"z":1.50,"a":"<b>&","nested":{"email":"[REDACTED]","list":[{"phone":"[REDACTED]"},null,true]}}`
+
+	output, err := s.sanitizeJSONOrdered([]byte(input), jsonOrderedOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(output) != expected {
+		t.Errorf("Unexpected output:\n got: %s\nwant: %s", output, expected)
+	}
+}